@@ -0,0 +1,130 @@
+package gqlgen_opentelemetry
+
+import (
+	"fmt"
+
+	"github.com/vektah/gqlparser/v2/ast"
+)
+
+type SuspiciousThresholds struct {
+	AliasCount          int
+	Depth               int
+	DirectiveCount      int
+	DuplicateFieldCount int
+	FragmentSpreadCount int
+}
+
+func (w *operationWalker) visitDeprecations(field *ast.Field) {
+	if field.ObjectDefinition == nil || field.ObjectDefinition.BuiltIn || field.Definition == nil {
+		return
+	}
+	typeName := field.ObjectDefinition.Name
+	if isDeprecated(field.Definition.Directives) {
+		w.addDeprecated(fieldCoordinate(typeName, field.Name))
+	}
+	for _, arg := range field.Arguments {
+		if def := field.Definition.Arguments.ForName(arg.Name); def != nil && isDeprecated(def.Directives) {
+			w.addDeprecated(argumentCoordinate(typeName, field.Name, arg.Name))
+		}
+		w.visitValue(arg.Value)
+	}
+}
+
+func (w *operationWalker) visitValue(value *ast.Value) {
+	if value == nil {
+		return
+	}
+	switch value.Kind {
+	case ast.EnumValue:
+		w.visitEnumValue(value.Definition, value.Raw)
+	case ast.Variable:
+		w.visitVariableValue(value.Definition, w.variables[value.Raw])
+	case ast.ListValue:
+		for _, child := range value.Children {
+			w.visitValue(child.Value)
+		}
+	case ast.ObjectValue:
+		for _, child := range value.Children {
+			w.visitInputField(value.Definition, child.Name)
+			w.visitValue(child.Value)
+		}
+	}
+}
+
+func (w *operationWalker) visitVariableValue(def *ast.Definition, value interface{}) {
+	if def == nil {
+		return
+	}
+	switch v := value.(type) {
+	case string:
+		w.visitEnumValue(def, v)
+	case fmt.Stringer:
+		w.visitEnumValue(def, v.String())
+	case []interface{}:
+		for _, item := range v {
+			w.visitVariableValue(def, item)
+		}
+	case map[string]interface{}:
+		for name, item := range v {
+			if field := w.visitInputField(def, name); field != nil {
+				w.visitVariableValue(w.lookupType(field.Type), item)
+			}
+		}
+	}
+}
+
+func (w *operationWalker) visitInputField(def *ast.Definition, name string) *ast.FieldDefinition {
+	if def == nil || def.Kind != ast.InputObject {
+		return nil
+	}
+	field := def.Fields.ForName(name)
+	if field != nil && isDeprecated(field.Directives) {
+		w.addDeprecated(fieldCoordinate(def.Name, name))
+	}
+	return field
+}
+
+// lookupType resolves the types nested inside variable values, which are only
+// known when the schema is, as variables carry no definitions of their own.
+func (w *operationWalker) lookupType(t *ast.Type) *ast.Definition {
+	if w.schema == nil || t == nil {
+		return nil
+	}
+	return w.schema.Types[t.Name()]
+}
+
+func (w *operationWalker) visitEnumValue(def *ast.Definition, name string) {
+	if def == nil || def.Kind != ast.Enum {
+		return
+	}
+	if value := def.EnumValues.ForName(name); value != nil && isDeprecated(value.Directives) {
+		w.addDeprecated(fieldCoordinate(def.Name, name))
+	}
+}
+
+func (w *operationWalker) addDeprecated(coordinate string) {
+	if !w.deprecated[coordinate] {
+		w.deprecated[coordinate] = true
+		w.stats.Deprecated = append(w.stats.Deprecated, coordinate)
+	}
+}
+
+func (s operationStats) suspiciousReasons(thresholds SuspiciousThresholds) []string {
+	var reasons []string
+	if thresholds.AliasCount > 0 && s.AliasCount > thresholds.AliasCount {
+		reasons = append(reasons, "alias_count")
+	}
+	if thresholds.Depth > 0 && s.Depth > thresholds.Depth {
+		reasons = append(reasons, "depth")
+	}
+	if thresholds.DirectiveCount > 0 && s.DirectiveCount > thresholds.DirectiveCount {
+		reasons = append(reasons, "directive_count")
+	}
+	if thresholds.DuplicateFieldCount > 0 && s.DuplicateFieldCount > thresholds.DuplicateFieldCount {
+		reasons = append(reasons, "duplicate_field_count")
+	}
+	if thresholds.FragmentSpreadCount > 0 && s.FragmentSpreadCount > thresholds.FragmentSpreadCount {
+		reasons = append(reasons, "fragment_spread_count")
+	}
+	return reasons
+}
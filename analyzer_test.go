@@ -0,0 +1,90 @@
+package gqlgen_opentelemetry
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/vektah/gqlparser/v2"
+	"github.com/zhevron/gqlgen-opentelemetry/v2/testserver"
+	"github.com/zhevron/gqlgen-opentelemetry/v2/testserver/generated"
+)
+
+func TestGetOperationStats_Deprecated(t *testing.T) {
+	schema := generated.NewExecutableSchema(generated.Config{
+		Resolvers: &testserver.Resolver{},
+	}).Schema()
+	doc, err := gqlparser.LoadQuery(schema, `
+		query ($language: Language!) {
+			hello
+			a: localizedGreeting(language: OLD_NORSE, formal: true)
+			b: localizedGreeting(language: $language)
+			c: localizedGreeting(language: ENGLISH)
+		}
+	`)
+	require.Nil(t, err)
+
+	stats := getOperationStats(schema, doc, doc.Operations[0], map[string]interface{}{"language": "OLD_NORSE"})
+	assert.Equal(t, []string{
+		"Language.OLD_NORSE",
+		"Query.hello",
+		"Query.localizedGreeting(formal:)",
+	}, stats.Deprecated)
+}
+
+func TestGetOperationStats_DeprecatedInputFields(t *testing.T) {
+	schema := generated.NewExecutableSchema(generated.Config{
+		Resolvers: &testserver.Resolver{},
+	}).Schema()
+	doc, err := gqlparser.LoadQuery(schema, `
+		query ($input: GreetingInput!) {
+			a: customGreeting(input: {name: "gqlgen", title: "Dr."})
+			b: customGreeting(input: $input)
+		}
+	`)
+	require.Nil(t, err)
+	variables := map[string]interface{}{
+		"input": map[string]interface{}{"name": "gqlgen", "language": "OLD_NORSE"},
+	}
+
+	stats := getOperationStats(schema, doc, doc.Operations[0], variables)
+	assert.Equal(t, []string{
+		"GreetingInput.title",
+		"Language.OLD_NORSE",
+	}, stats.Deprecated)
+}
+
+func TestGetOperationStats_DeprecatedVariableInputFields(t *testing.T) {
+	schema := generated.NewExecutableSchema(generated.Config{
+		Resolvers: &testserver.Resolver{},
+	}).Schema()
+	doc, err := gqlparser.LoadQuery(schema, `
+		query ($input: GreetingInput!) {
+			customGreeting(input: $input)
+		}
+	`)
+	require.Nil(t, err)
+	variables := map[string]interface{}{
+		"input": map[string]interface{}{"name": "gqlgen", "title": "Dr.", "language": "OLD_NORSE"},
+	}
+
+	stats := getOperationStats(schema, doc, doc.Operations[0], variables)
+	assert.Equal(t, []string{"GreetingInput.title", "Language.OLD_NORSE"}, stats.Deprecated)
+
+	stats = getOperationStats(nil, doc, doc.Operations[0], variables)
+	assert.Equal(t, []string{"GreetingInput.title"}, stats.Deprecated)
+}
+
+func TestOperationStats_SuspiciousReasons(t *testing.T) {
+	stats := operationStats{
+		AliasCount:          10,
+		Depth:               3,
+		FragmentSpreadCount: 5,
+	}
+	assert.Empty(t, stats.suspiciousReasons(SuspiciousThresholds{}))
+	assert.Equal(t, []string{"alias_count", "fragment_spread_count"}, stats.suspiciousReasons(SuspiciousThresholds{
+		AliasCount:          5,
+		Depth:               3,
+		FragmentSpreadCount: 1,
+	}))
+}
@@ -0,0 +1,22 @@
+package gqlgen_opentelemetry
+
+import (
+	"context"
+
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/baggage"
+)
+
+func withOperationBaggage(ctx context.Context, attrs ...attribute.KeyValue) context.Context {
+	b := baggage.FromContext(ctx)
+	for _, attr := range attrs {
+		member, err := baggage.NewMemberRaw(string(attr.Key), attr.Value.Emit())
+		if err != nil {
+			continue
+		}
+		if next, err := b.SetMember(member); err == nil {
+			b = next
+		}
+	}
+	return baggage.ContextWithBaggage(ctx, b)
+}
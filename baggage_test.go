@@ -0,0 +1,27 @@
+package gqlgen_opentelemetry
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/baggage"
+)
+
+func TestWithOperationBaggage(t *testing.T) {
+	member, _ := baggage.NewMember("tenant", "acme")
+	existing, _ := baggage.New(member)
+	ctx := baggage.ContextWithBaggage(context.Background(), existing)
+
+	ctx = withOperationBaggage(ctx,
+		attribute.String("graphql.operation.name", "Get Greeting"),
+		attribute.String("graphql.operation.type", "query"),
+	)
+
+	b := baggage.FromContext(ctx)
+	assert.Equal(t, 3, b.Len())
+	assert.Equal(t, "acme", b.Member("tenant").Value())
+	assert.Equal(t, "Get Greeting", b.Member("graphql.operation.name").Value())
+	assert.Equal(t, "query", b.Member("graphql.operation.type").Value())
+}
@@ -0,0 +1,49 @@
+package gqlgen_opentelemetry
+
+import (
+	"reflect"
+	"sync"
+	"sync/atomic"
+
+	"github.com/vektah/gqlparser/v2/ast"
+	"go.opentelemetry.io/otel/metric"
+	"go.opentelemetry.io/otel/trace"
+)
+
+type tracerCache struct {
+	instruments atomic.Pointer[cachedInstruments]
+	schema      atomic.Pointer[ast.Schema]
+	tracers     sync.Map
+}
+
+type cachedInstruments struct {
+	instruments *instruments
+	provider    metric.MeterProvider
+}
+
+// getTracer keeps a tracer for every provider it is called with, as the
+// provider of the parent span and the configured provider may alternate.
+func (c *tracerCache) getTracer(tp trace.TracerProvider) trace.Tracer {
+	if !reflect.TypeOf(tp).Comparable() {
+		return newTracer(tp)
+	}
+	if tracer, ok := c.tracers.Load(tp); ok {
+		return tracer.(trace.Tracer)
+	}
+	tracer, _ := c.tracers.LoadOrStore(tp, newTracer(tp))
+	return tracer.(trace.Tracer)
+}
+
+func (c *tracerCache) getInstruments(mp metric.MeterProvider) *instruments {
+	if cached := c.instruments.Load(); cached != nil && sameProvider(cached.provider, mp) {
+		return cached.instruments
+	}
+	i := newInstruments(mp)
+	c.instruments.Store(&cachedInstruments{instruments: i, provider: mp})
+	return i
+}
+
+func sameProvider(a, b interface{}) bool {
+	t := reflect.TypeOf(a)
+	return t == reflect.TypeOf(b) && t.Comparable() && a == b
+}
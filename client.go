@@ -0,0 +1,42 @@
+package gqlgen_opentelemetry
+
+import (
+	"context"
+
+	"github.com/99designs/gqlgen/graphql"
+	"go.opentelemetry.io/otel/attribute"
+)
+
+const (
+	defaultClientNameHeader    = "apollographql-client-name"
+	defaultClientVersionHeader = "apollographql-client-version"
+)
+
+func (t Tracer) getClientInfo(ctx context.Context, oc *graphql.OperationContext) (string, string) {
+	if t.ClientInfoFunc != nil {
+		return t.ClientInfoFunc(ctx)
+	}
+	if oc.Headers == nil {
+		return "", ""
+	}
+	nameHeader := t.ClientNameHeader
+	if nameHeader == "" {
+		nameHeader = defaultClientNameHeader
+	}
+	versionHeader := t.ClientVersionHeader
+	if versionHeader == "" {
+		versionHeader = defaultClientVersionHeader
+	}
+	return oc.Headers.Get(nameHeader), oc.Headers.Get(versionHeader)
+}
+
+func makeClientAttributes(name, version string) []attribute.KeyValue {
+	var attrs []attribute.KeyValue
+	if name != "" {
+		attrs = append(attrs, graphqlClientName.String(name))
+	}
+	if version != "" {
+		attrs = append(attrs, graphqlClientVersion.String(version))
+	}
+	return attrs
+}
@@ -0,0 +1,59 @@
+package gqlgen_opentelemetry
+
+import (
+	"context"
+	"net/http"
+	"testing"
+
+	"github.com/99designs/gqlgen/graphql"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestGetClientInfo_DefaultHeaders(t *testing.T) {
+	oc := &graphql.OperationContext{Headers: http.Header{}}
+	oc.Headers.Set("apollographql-client-name", "web")
+	oc.Headers.Set("apollographql-client-version", "1.2.3")
+
+	name, version := Tracer{}.getClientInfo(context.Background(), oc)
+	assert.Equal(t, "web", name)
+	assert.Equal(t, "1.2.3", version)
+}
+
+func TestGetClientInfo_CustomHeaders(t *testing.T) {
+	oc := &graphql.OperationContext{Headers: http.Header{}}
+	oc.Headers.Set("apollographql-client-name", "web")
+	oc.Headers.Set("x-client-name", "ios")
+	oc.Headers.Set("x-client-version", "4.5.6")
+
+	name, version := Tracer{
+		ClientNameHeader:    "x-client-name",
+		ClientVersionHeader: "x-client-version",
+	}.getClientInfo(context.Background(), oc)
+	assert.Equal(t, "ios", name)
+	assert.Equal(t, "4.5.6", version)
+}
+
+func TestGetClientInfo_Func(t *testing.T) {
+	oc := &graphql.OperationContext{Headers: http.Header{}}
+	oc.Headers.Set("apollographql-client-name", "web")
+
+	name, version := Tracer{
+		ClientInfoFunc: func(ctx context.Context) (string, string) {
+			return "android", "7.8.9"
+		},
+	}.getClientInfo(context.Background(), oc)
+	assert.Equal(t, "android", name)
+	assert.Equal(t, "7.8.9", version)
+}
+
+func TestGetClientInfo_NoHeaders(t *testing.T) {
+	name, version := Tracer{}.getClientInfo(context.Background(), &graphql.OperationContext{})
+	assert.Empty(t, name)
+	assert.Empty(t, version)
+}
+
+func TestMakeClientAttributes(t *testing.T) {
+	assert.Empty(t, makeClientAttributes("", ""))
+	assert.Len(t, makeClientAttributes("web", ""), 1)
+	assert.Len(t, makeClientAttributes("web", "1.2.3"), 2)
+}
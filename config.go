@@ -0,0 +1,97 @@
+package gqlgen_opentelemetry
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"strconv"
+
+	"gopkg.in/yaml.v3"
+)
+
+const (
+	envConfigFile     = "OTEL_GQLGEN_CONFIG_FILE"
+	envDocument       = "OTEL_GQLGEN_DOCUMENT"
+	envFieldArguments = "OTEL_GQLGEN_FIELD_ARGUMENTS"
+	envFieldResults   = "OTEL_GQLGEN_FIELD_RESULTS"
+	envFieldSpans     = "OTEL_GQLGEN_FIELD_SPANS"
+	envVariables      = "OTEL_GQLGEN_VARIABLES"
+)
+
+type Settings struct {
+	Document       *bool `json:"document,omitempty" yaml:"document"`
+	FieldArguments *bool `json:"fieldArguments,omitempty" yaml:"fieldArguments"`
+	FieldResults   *bool `json:"fieldResults,omitempty" yaml:"fieldResults"`
+	FieldSpans     *bool `json:"fieldSpans,omitempty" yaml:"fieldSpans"`
+	Variables      *bool `json:"variables,omitempty" yaml:"variables"`
+}
+
+func (t *Tracer) applyExternalConfig(lookupEnv func(string) (string, bool)) error {
+	path := t.configFile
+	if value, ok := lookupEnv(envConfigFile); ok && value != "" {
+		path = value
+	}
+	if path != "" {
+		if err := t.applyConfigFile(path); err != nil {
+			return err
+		}
+	}
+	return t.applyEnv(lookupEnv)
+}
+
+func (t *Tracer) applyConfigFile(path string) error {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return fmt.Errorf("failed to read config file: %w", err)
+	}
+	var settings Settings
+	decoder := yaml.NewDecoder(bytes.NewReader(data))
+	decoder.KnownFields(true)
+	if err := decoder.Decode(&settings); err != nil && !errors.Is(err, io.EOF) {
+		return fmt.Errorf("failed to parse config file %s: %w", path, err)
+	}
+	t.applySettings(settings)
+	return nil
+}
+
+func (t *Tracer) applySettings(settings Settings) {
+	if settings.Document != nil {
+		t.OmitDocument = !*settings.Document
+	}
+	if settings.FieldArguments != nil {
+		t.IncludeFieldArguments = *settings.FieldArguments
+	}
+	if settings.FieldResults != nil {
+		t.IncludeFieldResults = *settings.FieldResults
+	}
+	if settings.FieldSpans != nil {
+		t.IncludeFieldSpans = *settings.FieldSpans
+	}
+	if settings.Variables != nil {
+		t.IncludeVariables = *settings.Variables
+	}
+}
+
+func (t *Tracer) applyEnv(lookupEnv func(string) (string, bool)) error {
+	var errs []error
+	lookupBool := func(name string, apply func(bool)) {
+		value, ok := lookupEnv(name)
+		if !ok || value == "" {
+			return
+		}
+		b, err := strconv.ParseBool(value)
+		if err != nil {
+			errs = append(errs, fmt.Errorf("invalid value %q for %s: expected a boolean", value, name))
+			return
+		}
+		apply(b)
+	}
+	lookupBool(envDocument, func(b bool) { t.OmitDocument = !b })
+	lookupBool(envFieldArguments, func(b bool) { t.IncludeFieldArguments = b })
+	lookupBool(envFieldResults, func(b bool) { t.IncludeFieldResults = b })
+	lookupBool(envFieldSpans, func(b bool) { t.IncludeFieldSpans = b })
+	lookupBool(envVariables, func(b bool) { t.IncludeVariables = b })
+	return errors.Join(errs...)
+}
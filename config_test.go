@@ -0,0 +1,101 @@
+package gqlgen_opentelemetry
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/zhevron/gqlgen-opentelemetry/v2/testserver"
+	"github.com/zhevron/gqlgen-opentelemetry/v2/testserver/generated"
+)
+
+func writeConfigFile(t *testing.T, name, content string) string {
+	path := filepath.Join(t.TempDir(), name)
+	require.NoError(t, os.WriteFile(path, []byte(content), 0o600))
+	return path
+}
+
+func TestNew_Env(t *testing.T) {
+	t.Setenv(envDocument, "false")
+	t.Setenv(envFieldSpans, "true")
+	t.Setenv(envVariables, "1")
+
+	tracer := New()
+	assert.NoError(t, tracer.configErr)
+	assert.True(t, tracer.OmitDocument)
+	assert.True(t, tracer.IncludeFieldSpans)
+	assert.True(t, tracer.IncludeVariables)
+}
+
+func TestNew_EnvOverridesCode(t *testing.T) {
+	t.Setenv(envFieldSpans, "false")
+
+	tracer := New(WithFieldSpans(), WithVariables())
+	assert.False(t, tracer.IncludeFieldSpans)
+	assert.True(t, tracer.IncludeVariables)
+}
+
+func TestNew_EnvInvalid(t *testing.T) {
+	t.Setenv(envVariables, "yes please")
+
+	tracer := New()
+	assert.ErrorContains(t, tracer.configErr, envVariables)
+	assert.False(t, tracer.IncludeVariables)
+
+	schema := generated.NewExecutableSchema(generated.Config{
+		Resolvers: &testserver.Resolver{},
+	})
+	assert.ErrorContains(t, tracer.Validate(schema), envVariables)
+}
+
+func TestNew_ConfigFile_YAML(t *testing.T) {
+	path := writeConfigFile(t, "config.yaml", "fieldSpans: true\nfieldArguments: true\ndocument: false\n")
+
+	tracer := New(WithConfigFile(path), WithVariables())
+	assert.NoError(t, tracer.configErr)
+	assert.True(t, tracer.IncludeFieldSpans)
+	assert.True(t, tracer.IncludeFieldArguments)
+	assert.True(t, tracer.IncludeVariables)
+	assert.True(t, tracer.OmitDocument)
+}
+
+func TestNew_ConfigFile_JSON(t *testing.T) {
+	path := writeConfigFile(t, "config.json", `{"variables": true, "fieldSpans": false}`)
+
+	tracer := New(WithConfigFile(path), WithFieldSpans())
+	assert.NoError(t, tracer.configErr)
+	assert.True(t, tracer.IncludeVariables)
+	assert.False(t, tracer.IncludeFieldSpans)
+}
+
+func TestNew_ConfigFile_Env(t *testing.T) {
+	path := writeConfigFile(t, "config.yaml", "fieldSpans: true\nvariables: true\n")
+	t.Setenv(envConfigFile, path)
+	t.Setenv(envVariables, "false")
+
+	tracer := New()
+	assert.NoError(t, tracer.configErr)
+	assert.True(t, tracer.IncludeFieldSpans)
+	assert.False(t, tracer.IncludeVariables)
+}
+
+func TestNew_ConfigFile_Empty(t *testing.T) {
+	path := writeConfigFile(t, "config.yaml", "")
+
+	tracer := New(WithConfigFile(path))
+	assert.NoError(t, tracer.configErr)
+}
+
+func TestNew_ConfigFile_UnknownField(t *testing.T) {
+	path := writeConfigFile(t, "config.yaml", "fieldSpan: true\n")
+
+	tracer := New(WithConfigFile(path))
+	assert.ErrorContains(t, tracer.configErr, "failed to parse config file")
+}
+
+func TestNew_ConfigFile_Missing(t *testing.T) {
+	tracer := New(WithConfigFile(filepath.Join(t.TempDir(), "missing.yaml")))
+	assert.ErrorContains(t, tracer.configErr, "failed to read config file")
+}
@@ -0,0 +1,77 @@
+package gqlgen_opentelemetry
+
+import (
+	"context"
+	"strconv"
+
+	"github.com/99designs/gqlgen/graphql"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/trace"
+)
+
+const defaultDebugHeader = "X-GraphQL-Debug"
+
+type debugKey struct{}
+
+type debugSampler struct {
+	base sdktrace.Sampler
+}
+
+func (t Tracer) isDebugRequest(ctx context.Context, oc *graphql.OperationContext) bool {
+	if t.DebugAuthorizer == nil || oc.Headers == nil {
+		return false
+	}
+	header := t.DebugHeader
+	if header == "" {
+		header = defaultDebugHeader
+	}
+	if enabled, err := strconv.ParseBool(oc.Headers.Get(header)); err != nil || !enabled {
+		return false
+	}
+	return t.DebugAuthorizer(ctx)
+}
+
+func (t *Tracer) applyDebug() {
+	t.IncludeFieldSpans = true
+	t.IncludeVariables = true
+	t.OmitDocument = false
+}
+
+func withDebug(ctx context.Context) context.Context {
+	return context.WithValue(ctx, debugKey{}, true)
+}
+
+func isDebug(ctx context.Context) bool {
+	debug, _ := ctx.Value(debugKey{}).(bool)
+	return debug
+}
+
+func forceSampled(ctx context.Context) context.Context {
+	sc := trace.SpanContextFromContext(ctx)
+	if !sc.IsValid() || sc.IsSampled() {
+		return ctx
+	}
+	return trace.ContextWithRemoteSpanContext(ctx, sc.WithTraceFlags(sc.TraceFlags().WithSampled(true)))
+}
+
+// DebugSampler samples every span started inside a debug operation, and leaves
+// all other spans to the base sampler. Without it, debug operations are only
+// forced to be sampled when the base sampler respects the sampled flag of the
+// parent span, as ParentBased does, and the request has a parent span.
+func DebugSampler(base sdktrace.Sampler) sdktrace.Sampler {
+	return debugSampler{base: base}
+}
+
+func (s debugSampler) ShouldSample(p sdktrace.SamplingParameters) sdktrace.SamplingResult {
+	if isDebug(p.ParentContext) {
+		return sdktrace.SamplingResult{
+			Decision:   sdktrace.RecordAndSample,
+			Tracestate: trace.SpanContextFromContext(p.ParentContext).TraceState(),
+		}
+	}
+	return s.base.ShouldSample(p)
+}
+
+func (s debugSampler) Description() string {
+	return "DebugSampler{" + s.base.Description() + "}"
+}
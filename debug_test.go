@@ -0,0 +1,78 @@
+package gqlgen_opentelemetry
+
+import (
+	"context"
+	"net/http"
+	"testing"
+
+	"github.com/99designs/gqlgen/graphql"
+	"github.com/stretchr/testify/assert"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/trace"
+)
+
+func TestIsDebugRequest(t *testing.T) {
+	authorized := true
+	tracer := Tracer{
+		DebugAuthorizer: func(ctx context.Context) bool {
+			return authorized
+		},
+	}
+	oc := &graphql.OperationContext{Headers: http.Header{}}
+	assert.False(t, tracer.isDebugRequest(context.Background(), oc))
+
+	oc.Headers.Set(defaultDebugHeader, "true")
+	assert.True(t, tracer.isDebugRequest(context.Background(), oc))
+
+	authorized = false
+	assert.False(t, tracer.isDebugRequest(context.Background(), oc))
+
+	authorized = true
+	oc.Headers.Set(defaultDebugHeader, "nope")
+	assert.False(t, tracer.isDebugRequest(context.Background(), oc))
+}
+
+func TestIsDebugRequest_CustomHeader(t *testing.T) {
+	tracer := Tracer{
+		DebugHeader: "X-Debug",
+		DebugAuthorizer: func(ctx context.Context) bool {
+			return true
+		},
+	}
+	oc := &graphql.OperationContext{Headers: http.Header{}}
+	oc.Headers.Set(defaultDebugHeader, "true")
+	assert.False(t, tracer.isDebugRequest(context.Background(), oc))
+
+	oc.Headers.Set("X-Debug", "1")
+	assert.True(t, tracer.isDebugRequest(context.Background(), oc))
+}
+
+func TestIsDebugRequest_WithoutAuthorizer(t *testing.T) {
+	oc := &graphql.OperationContext{Headers: http.Header{}}
+	oc.Headers.Set(defaultDebugHeader, "true")
+	assert.False(t, Tracer{}.isDebugRequest(context.Background(), oc))
+}
+
+func TestForceSampled(t *testing.T) {
+	sc := trace.NewSpanContext(trace.SpanContextConfig{
+		TraceID: trace.TraceID{1},
+		SpanID:  trace.SpanID{1},
+	})
+	ctx := forceSampled(trace.ContextWithRemoteSpanContext(context.Background(), sc))
+	assert.True(t, trace.SpanContextFromContext(ctx).IsSampled())
+	assert.Equal(t, sc.TraceID(), trace.SpanContextFromContext(ctx).TraceID())
+
+	ctx = context.Background()
+	assert.Equal(t, ctx, forceSampled(ctx))
+}
+
+func TestDebugSampler(t *testing.T) {
+	sampler := DebugSampler(sdktrace.NeverSample())
+	assert.Equal(t, "DebugSampler{AlwaysOffSampler}", sampler.Description())
+
+	result := sampler.ShouldSample(sdktrace.SamplingParameters{ParentContext: context.Background()})
+	assert.Equal(t, sdktrace.Drop, result.Decision)
+
+	result = sampler.ShouldSample(sdktrace.SamplingParameters{ParentContext: withDebug(context.Background())})
+	assert.Equal(t, sdktrace.RecordAndSample, result.Decision)
+}
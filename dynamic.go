@@ -0,0 +1,153 @@
+package gqlgen_opentelemetry
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"sync/atomic"
+	"time"
+)
+
+type DynamicConfig struct {
+	current atomic.Pointer[dynamicSnapshot]
+	now     func() time.Time
+	tracer  atomic.Pointer[Tracer]
+}
+
+type dynamicSnapshot struct {
+	expiresAt time.Time
+	settings  Settings
+}
+
+type dynamicConfigStatus struct {
+	Settings
+	ExpiresAt time.Time `json:"expiresAt,omitzero"`
+}
+
+type dynamicSettingsKey struct{}
+
+type dynamicConfigRequest struct {
+	Settings
+	TTL string `json:"ttl,omitempty"`
+}
+
+func NewDynamicConfig() *DynamicConfig {
+	return &DynamicConfig{}
+}
+
+func (c *DynamicConfig) Set(settings Settings, ttl time.Duration) error {
+	if err := c.validate(settings); err != nil {
+		return err
+	}
+	snapshot := &dynamicSnapshot{settings: settings}
+	if ttl > 0 {
+		snapshot.expiresAt = c.getNow().Add(ttl)
+	}
+	c.current.Store(snapshot)
+	return nil
+}
+
+func (c *DynamicConfig) Reset() {
+	c.current.Store(nil)
+}
+
+func (c *DynamicConfig) Settings() (Settings, time.Time) {
+	snapshot := c.load()
+	if snapshot == nil {
+		return Settings{}, time.Time{}
+	}
+	return snapshot.settings, snapshot.expiresAt
+}
+
+func (c *DynamicConfig) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	switch r.Method {
+	case http.MethodGet, http.MethodHead:
+	case http.MethodPut, http.MethodPost:
+		var req dynamicConfigRequest
+		decoder := json.NewDecoder(r.Body)
+		decoder.DisallowUnknownFields()
+		if err := decoder.Decode(&req); err != nil {
+			http.Error(w, fmt.Sprintf("invalid request body: %v", err), http.StatusBadRequest)
+			return
+		}
+		var ttl time.Duration
+		if req.TTL != "" {
+			var err error
+			if ttl, err = time.ParseDuration(req.TTL); err != nil || ttl < 0 {
+				http.Error(w, fmt.Sprintf("invalid ttl %q", req.TTL), http.StatusBadRequest)
+				return
+			}
+		}
+		if err := c.Set(req.Settings, ttl); err != nil {
+			http.Error(w, fmt.Sprintf("invalid settings: %v", err), http.StatusBadRequest)
+			return
+		}
+	case http.MethodDelete:
+		c.Reset()
+	default:
+		w.Header().Set("Allow", "GET, HEAD, PUT, POST, DELETE")
+		http.Error(w, http.StatusText(http.StatusMethodNotAllowed), http.StatusMethodNotAllowed)
+		return
+	}
+	settings, expiresAt := c.Settings()
+	w.Header().Set("Content-Type", "application/json")
+	_ = json.NewEncoder(w).Encode(dynamicConfigStatus{
+		Settings:  settings,
+		ExpiresAt: expiresAt,
+	})
+}
+
+func (c *DynamicConfig) load() *dynamicSnapshot {
+	snapshot := c.current.Load()
+	if snapshot == nil {
+		return nil
+	}
+	if !snapshot.expiresAt.IsZero() && !c.getNow().Before(snapshot.expiresAt) {
+		c.current.CompareAndSwap(snapshot, nil)
+		return nil
+	}
+	return snapshot
+}
+
+// validate checks the settings against the configuration of the extension the
+// DynamicConfig was added to, as some settings depend on others.
+func (c *DynamicConfig) validate(settings Settings) error {
+	base := c.tracer.Load()
+	if base == nil {
+		return nil
+	}
+	t := *base
+	t.applySettings(settings)
+	return t.validateConfig()
+}
+
+func (c *DynamicConfig) getNow() time.Time {
+	if c.now != nil {
+		return c.now()
+	}
+	return time.Now()
+}
+
+func (t *Tracer) applyDynamicConfig(ctx context.Context) context.Context {
+	if t.DynamicConfig == nil {
+		return ctx
+	}
+	snapshot := t.DynamicConfig.load()
+	if snapshot == nil {
+		return ctx
+	}
+	dynamic := *t
+	dynamic.applySettings(snapshot.settings)
+	if dynamic.validateConfig() != nil {
+		return ctx
+	}
+	*t = dynamic
+	return context.WithValue(ctx, dynamicSettingsKey{}, snapshot.settings)
+}
+
+func (t *Tracer) applyDynamicSettings(ctx context.Context) {
+	if settings, ok := ctx.Value(dynamicSettingsKey{}).(Settings); ok {
+		t.applySettings(settings)
+	}
+}
@@ -0,0 +1,152 @@
+package gqlgen_opentelemetry
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/zhevron/gqlgen-opentelemetry/v2/testserver"
+	"github.com/zhevron/gqlgen-opentelemetry/v2/testserver/generated"
+)
+
+func boolPtr(b bool) *bool {
+	return &b
+}
+
+func TestDynamicConfig_Set(t *testing.T) {
+	c := NewDynamicConfig()
+	require.NoError(t, c.Set(Settings{FieldSpans: boolPtr(true)}, 0))
+
+	settings, expiresAt := c.Settings()
+	assert.Equal(t, boolPtr(true), settings.FieldSpans)
+	assert.True(t, expiresAt.IsZero())
+
+	c.Reset()
+	settings, _ = c.Settings()
+	assert.Equal(t, Settings{}, settings)
+}
+
+func TestDynamicConfig_Expiry(t *testing.T) {
+	now := time.Now()
+	c := NewDynamicConfig()
+	c.now = func() time.Time { return now }
+	require.NoError(t, c.Set(Settings{Variables: boolPtr(true)}, time.Minute))
+
+	settings, expiresAt := c.Settings()
+	assert.Equal(t, boolPtr(true), settings.Variables)
+	assert.Equal(t, now.Add(time.Minute), expiresAt)
+
+	now = now.Add(time.Minute)
+	settings, expiresAt = c.Settings()
+	assert.Equal(t, Settings{}, settings)
+	assert.True(t, expiresAt.IsZero())
+}
+
+func TestDynamicConfig_ApplyToTracer(t *testing.T) {
+	c := NewDynamicConfig()
+	tracer := Tracer{IncludeVariables: true, DynamicConfig: c}
+
+	tracer.applyDynamicConfig(context.Background())
+	assert.False(t, tracer.IncludeFieldSpans)
+	assert.True(t, tracer.IncludeVariables)
+
+	require.NoError(t, c.Set(Settings{FieldSpans: boolPtr(true), Variables: boolPtr(false), Document: boolPtr(false)}, 0))
+	tracer.applyDynamicConfig(context.Background())
+	assert.True(t, tracer.IncludeFieldSpans)
+	assert.False(t, tracer.IncludeVariables)
+	assert.True(t, tracer.OmitDocument)
+}
+
+func TestDynamicConfig_ApplyOncePerOperation(t *testing.T) {
+	c := NewDynamicConfig()
+	require.NoError(t, c.Set(Settings{FieldSpans: boolPtr(true)}, 0))
+
+	operation := Tracer{DynamicConfig: c}
+	ctx := operation.applyDynamicConfig(context.Background())
+	assert.True(t, operation.IncludeFieldSpans)
+
+	c.Reset()
+	field := Tracer{DynamicConfig: c}
+	field.applyDynamicSettings(ctx)
+	assert.True(t, field.IncludeFieldSpans)
+
+	field = Tracer{DynamicConfig: c}
+	field.applyDynamicSettings(context.Background())
+	assert.False(t, field.IncludeFieldSpans)
+}
+
+func TestDynamicConfig_ApplyInvalid(t *testing.T) {
+	c := NewDynamicConfig()
+	require.NoError(t, c.Set(Settings{FieldArguments: boolPtr(true)}, 0))
+
+	tracer := Tracer{DynamicConfig: c}
+	ctx := tracer.applyDynamicConfig(context.Background())
+	assert.False(t, tracer.IncludeFieldArguments)
+	assert.Nil(t, ctx.Value(dynamicSettingsKey{}))
+}
+
+func TestDynamicConfig_SetInvalid(t *testing.T) {
+	schema := generated.NewExecutableSchema(generated.Config{
+		Resolvers: &testserver.Resolver{},
+	})
+	c := NewDynamicConfig()
+	require.NoError(t, Tracer{DynamicConfig: c}.Validate(schema))
+
+	err := c.Set(Settings{FieldArguments: boolPtr(true)}, 0)
+	assert.ErrorContains(t, err, "IncludeFieldArguments requires IncludeFieldSpans")
+	settings, _ := c.Settings()
+	assert.Equal(t, Settings{}, settings)
+
+	require.NoError(t, c.Set(Settings{FieldArguments: boolPtr(true), FieldSpans: boolPtr(true)}, 0))
+
+	rec := httptest.NewRecorder()
+	c.ServeHTTP(rec, httptest.NewRequest(http.MethodPut, "/", strings.NewReader(`{"fieldResults": true, "fieldSpans": false}`)))
+	assert.Equal(t, http.StatusBadRequest, rec.Code)
+	assert.Contains(t, rec.Body.String(), "IncludeFieldResults requires IncludeFieldSpans")
+}
+
+func TestDynamicConfig_ServeHTTP(t *testing.T) {
+	c := NewDynamicConfig()
+
+	rec := httptest.NewRecorder()
+	c.ServeHTTP(rec, httptest.NewRequest(http.MethodPut, "/", strings.NewReader(`{"fieldSpans": true, "ttl": "5m"}`)))
+	require.Equal(t, http.StatusOK, rec.Code)
+
+	var status dynamicConfigStatus
+	require.NoError(t, json.NewDecoder(rec.Body).Decode(&status))
+	assert.Equal(t, boolPtr(true), status.FieldSpans)
+	assert.False(t, status.ExpiresAt.IsZero())
+
+	rec = httptest.NewRecorder()
+	c.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/", nil))
+	require.Equal(t, http.StatusOK, rec.Code)
+	assert.Contains(t, rec.Body.String(), `"fieldSpans":true`)
+
+	rec = httptest.NewRecorder()
+	c.ServeHTTP(rec, httptest.NewRequest(http.MethodDelete, "/", nil))
+	require.Equal(t, http.StatusOK, rec.Code)
+	settings, _ := c.Settings()
+	assert.Equal(t, Settings{}, settings)
+}
+
+func TestDynamicConfig_ServeHTTP_InvalidRequest(t *testing.T) {
+	c := NewDynamicConfig()
+
+	rec := httptest.NewRecorder()
+	c.ServeHTTP(rec, httptest.NewRequest(http.MethodPut, "/", strings.NewReader(`{"fieldSpan": true}`)))
+	assert.Equal(t, http.StatusBadRequest, rec.Code)
+
+	rec = httptest.NewRecorder()
+	c.ServeHTTP(rec, httptest.NewRequest(http.MethodPut, "/", strings.NewReader(`{"ttl": "soon"}`)))
+	assert.Equal(t, http.StatusBadRequest, rec.Code)
+
+	rec = httptest.NewRecorder()
+	c.ServeHTTP(rec, httptest.NewRequest(http.MethodPatch, "/", nil))
+	assert.Equal(t, http.StatusMethodNotAllowed, rec.Code)
+}
@@ -0,0 +1,33 @@
+package gqlgen_opentelemetry
+
+import (
+	"github.com/99designs/gqlgen/graphql"
+	"go.opentelemetry.io/otel/trace"
+)
+
+const (
+	spanIDExtension  = "spanId"
+	traceIDExtension = "traceId"
+)
+
+func (t Tracer) addTraceIDExtensions(res *graphql.Response, sc trace.SpanContext) {
+	if res == nil || !sc.IsValid() || !sc.IsSampled() {
+		return
+	}
+	if t.IncludeTraceIDExtension {
+		if res.Extensions == nil {
+			res.Extensions = map[string]interface{}{}
+		}
+		res.Extensions[traceIDExtension] = sc.TraceID().String()
+		res.Extensions[spanIDExtension] = sc.SpanID().String()
+	}
+	if t.IncludeTraceIDInErrors {
+		for _, err := range res.Errors {
+			if err.Extensions == nil {
+				err.Extensions = map[string]interface{}{}
+			}
+			err.Extensions[traceIDExtension] = sc.TraceID().String()
+			err.Extensions[spanIDExtension] = sc.SpanID().String()
+		}
+	}
+}
@@ -0,0 +1,48 @@
+package gqlgen_opentelemetry
+
+import (
+	"testing"
+
+	"github.com/99designs/gqlgen/graphql"
+	"github.com/stretchr/testify/assert"
+	"github.com/vektah/gqlparser/v2/gqlerror"
+	"go.opentelemetry.io/otel/trace"
+)
+
+func TestAddTraceIDExtensions(t *testing.T) {
+	sc := trace.NewSpanContext(trace.SpanContextConfig{
+		TraceID:    trace.TraceID{1},
+		SpanID:     trace.SpanID{2},
+		TraceFlags: trace.FlagsSampled,
+	})
+	res := &graphql.Response{Errors: gqlerror.List{gqlerror.Errorf("failed")}}
+
+	Tracer{IncludeTraceIDExtension: true}.addTraceIDExtensions(res, sc)
+	assert.Equal(t, sc.TraceID().String(), res.Extensions[traceIDExtension])
+	assert.Equal(t, sc.SpanID().String(), res.Extensions[spanIDExtension])
+	assert.Nil(t, res.Errors[0].Extensions)
+
+	Tracer{IncludeTraceIDInErrors: true}.addTraceIDExtensions(res, sc)
+	assert.Equal(t, sc.TraceID().String(), res.Errors[0].Extensions[traceIDExtension])
+	assert.Equal(t, sc.SpanID().String(), res.Errors[0].Extensions[spanIDExtension])
+}
+
+func TestAddTraceIDExtensions_InvalidSpanContext(t *testing.T) {
+	res := &graphql.Response{}
+	Tracer{IncludeTraceIDExtension: true}.addTraceIDExtensions(res, trace.SpanContext{})
+	assert.Nil(t, res.Extensions)
+
+	Tracer{IncludeTraceIDExtension: true}.addTraceIDExtensions(nil, trace.SpanContext{})
+}
+
+func TestAddTraceIDExtensions_NotSampled(t *testing.T) {
+	sc := trace.NewSpanContext(trace.SpanContextConfig{
+		TraceID: trace.TraceID{1},
+		SpanID:  trace.SpanID{2},
+	})
+	res := &graphql.Response{Errors: gqlerror.List{gqlerror.Errorf("failed")}}
+
+	Tracer{IncludeTraceIDExtension: true, IncludeTraceIDInErrors: true}.addTraceIDExtensions(res, sc)
+	assert.Nil(t, res.Extensions)
+	assert.Nil(t, res.Errors[0].Extensions)
+}
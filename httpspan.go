@@ -0,0 +1,64 @@
+package gqlgen_opentelemetry
+
+import (
+	"context"
+
+	"github.com/99designs/gqlgen/graphql"
+	"go.opentelemetry.io/otel/attribute"
+	semconv "go.opentelemetry.io/otel/semconv/v1.38.0"
+	"go.opentelemetry.io/otel/trace"
+)
+
+type HTTPSpanMode int
+
+const (
+	// HTTPSpanNested creates a server span for the operation, nested inside any existing HTTP server span.
+	HTTPSpanNested HTTPSpanMode = iota
+	// HTTPSpanReuse records the operation on the existing HTTP server span instead of creating a new span.
+	HTTPSpanReuse
+	// HTTPSpanChild annotates the existing HTTP server span and creates an internal span for the operation.
+	HTTPSpanChild
+)
+
+func (t Tracer) startOperationSpan(ctx context.Context, spanName string, attrs []attribute.KeyValue, opts ...trace.SpanStartOption) (context.Context, trace.Span, func()) {
+	if parent := trace.SpanFromContext(ctx); t.HTTPSpanMode != HTTPSpanNested && isHTTPServerSpan(parent) && !isWebsocketOperation(ctx) {
+		parent.SetName(spanName)
+		parent.SetAttributes(attrs...)
+		if t.HTTPRoute != "" {
+			parent.SetAttributes(semconv.HTTPRoute(t.HTTPRoute))
+		}
+		if t.HTTPSpanMode == HTTPSpanReuse {
+			parent.SetAttributes(baseAttributes...)
+			return ctx, parent, func() {}
+		}
+		opts = append(opts, trace.WithSpanKind(trace.SpanKindInternal), trace.WithAttributes(baseAttributes...))
+		ctx, span := t.getTracer(ctx).Start(ctx, spanName, opts...)
+		span.SetAttributes(attrs...)
+		return ctx, span, func() { span.End() }
+	}
+	opts = append(opts, trace.WithSpanKind(trace.SpanKindServer), trace.WithAttributes(baseAttributes...))
+	ctx, span := t.getTracer(ctx).Start(ctx, spanName, opts...)
+	span.SetAttributes(attrs...)
+	return ctx, span, func() { span.End() }
+}
+
+func isHTTPServerSpan(span trace.Span) bool {
+	sc := span.SpanContext()
+	if !sc.IsValid() || sc.IsRemote() || !span.IsRecording() {
+		return false
+	}
+	s, ok := span.(interface{ SpanKind() trace.SpanKind })
+	return ok && s.SpanKind() == trace.SpanKindServer
+}
+
+// isWebsocketOperation reports whether the operation arrived over a websocket,
+// whose HTTP server span covers the whole connection rather than one operation.
+func isWebsocketOperation(ctx context.Context) bool {
+	if getWebsocketConnection(ctx) != nil {
+		return true
+	}
+	if !graphql.HasOperationContext(ctx) {
+		return false
+	}
+	return graphql.GetOperationContext(ctx).Headers.Get("Upgrade") != ""
+}
@@ -0,0 +1,29 @@
+package gqlgen_opentelemetry
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/trace"
+)
+
+func TestIsHTTPServerSpan(t *testing.T) {
+	tracer := sdktrace.NewTracerProvider().Tracer("test")
+	_, serverSpan := tracer.Start(context.Background(), "server", trace.WithSpanKind(trace.SpanKindServer))
+	_, clientSpan := tracer.Start(context.Background(), "client", trace.WithSpanKind(trace.SpanKindClient))
+	remoteSpan := trace.SpanFromContext(trace.ContextWithRemoteSpanContext(context.Background(), serverSpan.SpanContext()))
+
+	assert.True(t, isHTTPServerSpan(serverSpan))
+	assert.False(t, isHTTPServerSpan(clientSpan))
+	assert.False(t, isHTTPServerSpan(remoteSpan))
+	assert.False(t, isHTTPServerSpan(trace.SpanFromContext(context.Background())))
+}
+
+func TestIsHTTPServerSpan_UnknownKind(t *testing.T) {
+	tracer := sdktrace.NewTracerProvider().Tracer("test")
+	_, serverSpan := tracer.Start(context.Background(), "server", trace.WithSpanKind(trace.SpanKindServer))
+
+	assert.False(t, isHTTPServerSpan(struct{ trace.Span }{serverSpan}))
+}
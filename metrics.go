@@ -0,0 +1,129 @@
+package gqlgen_opentelemetry
+
+import (
+	"context"
+
+	"github.com/99designs/gqlgen/graphql"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/metric"
+	"go.opentelemetry.io/otel/metric/noop"
+	semconv "go.opentelemetry.io/otel/semconv/v1.38.0"
+)
+
+type instruments struct {
+	aliasCount          metric.Int64Histogram
+	deprecatedUsage     metric.Int64Counter
+	directiveCount      metric.Int64Histogram
+	duplicateFieldCount metric.Int64Histogram
+	fragmentSpreadCount metric.Int64Histogram
+
+	websocketConnections      metric.Int64UpDownCounter
+	websocketInitFailures     metric.Int64Counter
+	websocketOutgoingMessages metric.Int64Histogram
+}
+
+const otherMetricValue = "other"
+
+var noopInstruments = newInstruments(noop.NewMeterProvider())
+
+func AllowList(names ...string) func(name string) string {
+	allowed := make(map[string]bool, len(names))
+	for _, name := range names {
+		allowed[name] = true
+	}
+	return func(name string) string {
+		if allowed[name] {
+			return name
+		}
+		return otherMetricValue
+	}
+}
+
+func newInstruments(mp metric.MeterProvider) *instruments {
+	meter := mp.Meter(extensionName, metric.WithInstrumentationVersion(extensionVersion))
+	i := &instruments{}
+	i.aliasCount, _ = meter.Int64Histogram(
+		string(graphqlAliasCount),
+		metric.WithDescription("Number of aliased fields selected by a GraphQL operation."),
+		metric.WithUnit("{field}"),
+	)
+	i.deprecatedUsage, _ = meter.Int64Counter(
+		graphqlDeprecatedUsage,
+		metric.WithDescription("Number of times a deprecated field, argument or enum value was used by a GraphQL operation."),
+		metric.WithUnit("{usage}"),
+	)
+	i.directiveCount, _ = meter.Int64Histogram(
+		string(graphqlDirectiveCount),
+		metric.WithDescription("Number of directives used by a GraphQL operation."),
+		metric.WithUnit("{directive}"),
+	)
+	i.duplicateFieldCount, _ = meter.Int64Histogram(
+		string(graphqlDuplicateFieldCount),
+		metric.WithDescription("Number of fields selected more than once in the same selection set of a GraphQL operation."),
+		metric.WithUnit("{field}"),
+	)
+	i.fragmentSpreadCount, _ = meter.Int64Histogram(
+		string(graphqlFragmentSpreadCount),
+		metric.WithDescription("Number of fragment spreads used by a GraphQL operation."),
+		metric.WithUnit("{spread}"),
+	)
+	i.websocketConnections, _ = meter.Int64UpDownCounter(
+		graphqlWebsocketConnections,
+		metric.WithDescription("Number of open GraphQL websocket connections."),
+		metric.WithUnit("{connection}"),
+	)
+	i.websocketInitFailures, _ = meter.Int64Counter(
+		graphqlWebsocketInitFailures,
+		metric.WithDescription("Number of GraphQL websocket connections rejected during initialisation."),
+		metric.WithUnit("{connection}"),
+	)
+	i.websocketOutgoingMessages, _ = meter.Int64Histogram(
+		graphqlWebsocketOutgoingMessages,
+		metric.WithDescription("Number of GraphQL responses sent over a websocket connection, excluding keep-alive pings."),
+		metric.WithUnit("{message}"),
+	)
+	return i
+}
+
+// getInstruments returns instruments that record nothing when the tracer was
+// not created with New, as there is no cache to keep the instruments in.
+func (t Tracer) getInstruments() *instruments {
+	if t.cache == nil {
+		return noopInstruments
+	}
+	mp := t.MeterProvider
+	if mp == nil {
+		mp = otel.GetMeterProvider()
+	}
+	return t.cache.getInstruments(mp)
+}
+
+func (t Tracer) makeMetricAttributes(ctx context.Context, oc *graphql.OperationContext) []attribute.KeyValue {
+	attrs := []attribute.KeyValue{getOperationTypeAttribute(oc)}
+	if operationName := getOperationName(oc); operationName != "" && t.MetricOperationName != nil {
+		if name := t.MetricOperationName(operationName); name != "" {
+			attrs = append(attrs, semconv.GraphQLOperationName(name))
+		}
+	}
+	if clientName, _ := t.getClientInfo(ctx, oc); clientName != "" && t.MetricClientName != nil {
+		if name := t.MetricClientName(clientName); name != "" {
+			attrs = append(attrs, graphqlClientName.String(name))
+		}
+	}
+	return attrs
+}
+
+func (i *instruments) recordOperationStats(ctx context.Context, stats operationStats, attrs ...attribute.KeyValue) {
+	opt := metric.WithAttributes(attrs...)
+	i.aliasCount.Record(ctx, int64(stats.AliasCount), opt)
+	i.directiveCount.Record(ctx, int64(stats.DirectiveCount), opt)
+	i.duplicateFieldCount.Record(ctx, int64(stats.DuplicateFieldCount), opt)
+	i.fragmentSpreadCount.Record(ctx, int64(stats.FragmentSpreadCount), opt)
+}
+
+func (i *instruments) recordDeprecatedUsage(ctx context.Context, stats operationStats, attrs ...attribute.KeyValue) {
+	for _, coordinate := range stats.Deprecated {
+		i.deprecatedUsage.Add(ctx, 1, metric.WithAttributes(append(attrs, graphqlSchemaCoordinate.String(coordinate))...))
+	}
+}
@@ -0,0 +1,15 @@
+package gqlgen_opentelemetry
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestAllowList(t *testing.T) {
+	f := AllowList("GetUser", "web")
+	assert.Equal(t, "GetUser", f("GetUser"))
+	assert.Equal(t, "web", f("web"))
+	assert.Equal(t, "other", f("GetUsers"))
+	assert.Equal(t, "other", f(""))
+}
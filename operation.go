@@ -0,0 +1,190 @@
+package gqlgen_opentelemetry
+
+import (
+	"context"
+	"math"
+	"slices"
+	"strings"
+
+	"github.com/vektah/gqlparser/v2/ast"
+	"go.opentelemetry.io/otel/attribute"
+)
+
+type operationStatsKey struct{}
+
+type operationStats struct {
+	AliasCount          int
+	Deprecated          []string
+	Depth               int
+	DirectiveCount      int
+	Directives          []string
+	DuplicateFieldCount int
+	FieldCount          int
+	Fields              []string
+	FragmentSpreadCount int
+}
+
+// fragmentStats holds the counts of a fragment walked on its own, with depth
+// relative to the spread and the names of its top-level fields, so that every
+// spread of the fragment can reuse them instead of walking it again.
+type fragmentStats struct {
+	fieldNames map[string]int
+	stats      operationStats
+}
+
+type operationWalker struct {
+	doc        *ast.QueryDocument
+	deprecated map[string]bool
+	directives map[string]bool
+	fields     map[string]bool
+	fragments  map[string]*fragmentStats
+	schema     *ast.Schema
+	stats      operationStats
+	variables  map[string]interface{}
+	visiting   map[string]bool
+}
+
+func getOperationStats(schema *ast.Schema, doc *ast.QueryDocument, op *ast.OperationDefinition, variables map[string]interface{}) operationStats {
+	if op == nil {
+		return operationStats{}
+	}
+	w := &operationWalker{
+		doc:        doc,
+		deprecated: map[string]bool{},
+		directives: map[string]bool{},
+		fields:     map[string]bool{},
+		fragments:  map[string]*fragmentStats{},
+		schema:     schema,
+		variables:  variables,
+		visiting:   map[string]bool{},
+	}
+	w.visitDirectives(&w.stats, op.Directives)
+	w.walkSelectionSet(&w.stats, op.SelectionSet, 1, map[string]int{})
+	slices.Sort(w.stats.Deprecated)
+	slices.Sort(w.stats.Directives)
+	slices.Sort(w.stats.Fields)
+	return w.stats
+}
+
+func withOperationStats(ctx context.Context, stats *operationStats) context.Context {
+	return context.WithValue(ctx, operationStatsKey{}, stats)
+}
+
+func getContextOperationStats(ctx context.Context) *operationStats {
+	stats, _ := ctx.Value(operationStatsKey{}).(*operationStats)
+	return stats
+}
+
+func (w *operationWalker) walkSelectionSet(stats *operationStats, selectionSet ast.SelectionSet, depth int, fieldNames map[string]int) {
+	for _, selection := range selectionSet {
+		switch sel := selection.(type) {
+		case *ast.Field:
+			stats.FieldCount = addCount(stats.FieldCount, 1)
+			if sel.Alias != "" && sel.Alias != sel.Name {
+				stats.AliasCount = addCount(stats.AliasCount, 1)
+			}
+			if fieldNames[sel.Name] > 0 {
+				stats.DuplicateFieldCount = addCount(stats.DuplicateFieldCount, 1)
+			}
+			fieldNames[sel.Name] = addCount(fieldNames[sel.Name], 1)
+			if depth > stats.Depth {
+				stats.Depth = depth
+			}
+			if sel.ObjectDefinition != nil && !strings.HasPrefix(sel.Name, "__") {
+				w.addField(fieldCoordinate(sel.ObjectDefinition.Name, sel.Name))
+			}
+			w.visitDirectives(stats, sel.Directives)
+			w.visitDeprecations(sel)
+			w.walkSelectionSet(stats, sel.SelectionSet, depth+1, map[string]int{})
+		case *ast.InlineFragment:
+			w.visitDirectives(stats, sel.Directives)
+			w.walkSelectionSet(stats, sel.SelectionSet, depth, fieldNames)
+		case *ast.FragmentSpread:
+			stats.FragmentSpreadCount = addCount(stats.FragmentSpreadCount, 1)
+			w.visitDirectives(stats, sel.Directives)
+			if f := w.fragmentStats(sel); f != nil {
+				f.mergeInto(stats, depth, fieldNames)
+			}
+		}
+	}
+}
+
+func (w *operationWalker) fragmentStats(spread *ast.FragmentSpread) *fragmentStats {
+	if f, ok := w.fragments[spread.Name]; ok {
+		return f
+	}
+	def := spread.Definition
+	if def == nil && w.doc != nil {
+		def = w.doc.Fragments.ForName(spread.Name)
+	}
+	if def == nil || w.visiting[spread.Name] {
+		return nil
+	}
+	w.visiting[spread.Name] = true
+	f := &fragmentStats{fieldNames: map[string]int{}}
+	w.walkSelectionSet(&f.stats, def.SelectionSet, 1, f.fieldNames)
+	delete(w.visiting, spread.Name)
+	w.fragments[spread.Name] = f
+	return f
+}
+
+func (f *fragmentStats) mergeInto(stats *operationStats, depth int, fieldNames map[string]int) {
+	stats.AliasCount = addCount(stats.AliasCount, f.stats.AliasCount)
+	stats.DirectiveCount = addCount(stats.DirectiveCount, f.stats.DirectiveCount)
+	stats.DuplicateFieldCount = addCount(stats.DuplicateFieldCount, f.stats.DuplicateFieldCount)
+	stats.FieldCount = addCount(stats.FieldCount, f.stats.FieldCount)
+	stats.FragmentSpreadCount = addCount(stats.FragmentSpreadCount, f.stats.FragmentSpreadCount)
+	if f.stats.Depth > 0 && depth-1+f.stats.Depth > stats.Depth {
+		stats.Depth = depth - 1 + f.stats.Depth
+	}
+	for name, count := range f.fieldNames {
+		if fieldNames[name] > 0 {
+			stats.DuplicateFieldCount = addCount(stats.DuplicateFieldCount, 1)
+		}
+		fieldNames[name] = addCount(fieldNames[name], count)
+	}
+}
+
+func (w *operationWalker) visitDirectives(stats *operationStats, directives ast.DirectiveList) {
+	for _, directive := range directives {
+		stats.DirectiveCount = addCount(stats.DirectiveCount, 1)
+		if !w.directives[directive.Name] {
+			w.directives[directive.Name] = true
+			w.stats.Directives = append(w.stats.Directives, directive.Name)
+		}
+	}
+}
+
+func (w *operationWalker) addField(coordinate string) {
+	if !w.fields[coordinate] {
+		w.fields[coordinate] = true
+		w.stats.Fields = append(w.stats.Fields, coordinate)
+	}
+}
+
+// addCount adds without overflowing, as the counts of nested fragment spreads
+// grow exponentially.
+func addCount(a, b int) int {
+	if a > math.MaxInt-b {
+		return math.MaxInt
+	}
+	return a + b
+}
+
+func (s operationStats) attributes() []attribute.KeyValue {
+	attrs := []attribute.KeyValue{
+		graphqlAliasCount.Int(s.AliasCount),
+		graphqlDepth.Int(s.Depth),
+		graphqlDirectiveCount.Int(s.DirectiveCount),
+		graphqlDuplicateFieldCount.Int(s.DuplicateFieldCount),
+		graphqlFieldCount.Int(s.FieldCount),
+		graphqlFragmentSpreadCount.Int(s.FragmentSpreadCount),
+	}
+	if len(s.Deprecated) > 0 {
+		attrs = append(attrs, graphqlDeprecated.StringSlice(s.Deprecated))
+	}
+	if len(s.Directives) > 0 {
+		attrs = append(attrs, graphqlDirectives.StringSlice(s.Directives))
+	}
+	return attrs
+}
@@ -0,0 +1,93 @@
+package gqlgen_opentelemetry
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/vektah/gqlparser/v2"
+	"github.com/vektah/gqlparser/v2/ast"
+	"github.com/vektah/gqlparser/v2/parser"
+	"github.com/zhevron/gqlgen-opentelemetry/v2/testserver"
+	"github.com/zhevron/gqlgen-opentelemetry/v2/testserver/generated"
+)
+
+func TestGetOperationStats(t *testing.T) {
+	schema := generated.NewExecutableSchema(generated.Config{
+		Resolvers: &testserver.Resolver{},
+	}).Schema()
+	doc, err := gqlparser.LoadQuery(schema, `
+		query {
+			greeting
+			hello: greeting @include(if: true)
+			node(id: "1") {
+				...NodeFields
+				... on Person @skip(if: false) { id name }
+			}
+		}
+		fragment NodeFields on Node { nodeId: id }
+	`)
+	require.Nil(t, err)
+
+	stats := getOperationStats(schema, doc, doc.Operations[0], nil)
+	assert.Equal(t, 2, stats.AliasCount)
+	assert.Equal(t, 2, stats.Depth)
+	assert.Equal(t, 2, stats.DirectiveCount)
+	assert.Equal(t, []string{"include", "skip"}, stats.Directives)
+	assert.Equal(t, 2, stats.DuplicateFieldCount)
+	assert.Equal(t, 6, stats.FieldCount)
+	assert.Equal(t, []string{"Node.id", "Person.id", "Person.name", "Query.greeting", "Query.node"}, stats.Fields)
+	assert.Equal(t, 1, stats.FragmentSpreadCount)
+}
+
+func TestGetOperationStats_Fragments(t *testing.T) {
+	schema := generated.NewExecutableSchema(generated.Config{
+		Resolvers: &testserver.Resolver{},
+	}).Schema()
+	doc, err := gqlparser.LoadQuery(schema, `
+		query {
+			greeting
+			node(id: "1") {
+				...NodeFields
+				...NodeFields
+			}
+			...QueryFields
+		}
+		fragment NodeFields on Node { id ... on Person { name } }
+		fragment QueryFields on Query { greeting node(id: "1") { ...NodeFields } }
+	`)
+	require.Nil(t, err)
+
+	stats := getOperationStats(schema, doc, doc.Operations[0], nil)
+	assert.Equal(t, 0, stats.AliasCount)
+	assert.Equal(t, 2, stats.Depth)
+	assert.Equal(t, 4, stats.DuplicateFieldCount)
+	assert.Equal(t, 10, stats.FieldCount)
+	assert.Equal(t, []string{"Node.id", "Person.name", "Query.greeting", "Query.node"}, stats.Fields)
+	assert.Equal(t, 4, stats.FragmentSpreadCount)
+}
+
+func TestGetOperationStats_FragmentBomb(t *testing.T) {
+	// Each fragment spreads the previous one twice, so walking every spread
+	// would visit 2^50 fields.
+	var b strings.Builder
+	b.WriteString("query { ...F50 }\nfragment F0 on Query { greeting }\n")
+	for i := 1; i <= 50; i++ {
+		fmt.Fprintf(&b, "fragment F%d on Query { ...F%d ...F%d }\n", i, i-1, i-1)
+	}
+	doc, err := parser.ParseQuery(&ast.Source{Input: b.String()})
+	require.Nil(t, err)
+
+	stats := getOperationStats(nil, doc, doc.Operations[0], nil)
+	assert.Equal(t, 1, stats.Depth)
+	assert.Equal(t, 1<<50-1, stats.DuplicateFieldCount)
+	assert.Equal(t, 1<<50, stats.FieldCount)
+	assert.Equal(t, 1<<51-1, stats.FragmentSpreadCount)
+}
+
+func TestGetOperationStats_NilOperation(t *testing.T) {
+	stats := getOperationStats(nil, nil, nil, nil)
+	assert.Equal(t, operationStats{}, stats)
+}
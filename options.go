@@ -0,0 +1,208 @@
+package gqlgen_opentelemetry
+
+import (
+	"context"
+	"errors"
+	"os"
+
+	"github.com/99designs/gqlgen/graphql"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/metric"
+	"go.opentelemetry.io/otel/propagation"
+	"go.opentelemetry.io/otel/trace"
+)
+
+type Option func(*Tracer)
+
+func New(opts ...Option) *Tracer {
+	t := &Tracer{}
+	for _, opt := range opts {
+		opt(t)
+	}
+	t.configErr = t.applyExternalConfig(os.LookupEnv)
+	t.cache = &tracerCache{}
+	return t
+}
+
+func WithBaggage(includeClient bool) Option {
+	return func(t *Tracer) {
+		t.OperationBaggage = true
+		t.ClientBaggage = includeClient
+	}
+}
+
+func WithClientHeaders(nameHeader, versionHeader string) Option {
+	return func(t *Tracer) {
+		t.ClientNameHeader = nameHeader
+		t.ClientVersionHeader = versionHeader
+	}
+}
+
+func WithClientInfoFunc(f func(ctx context.Context) (name, version string)) Option {
+	return func(t *Tracer) {
+		t.ClientInfoFunc = f
+	}
+}
+
+func WithConfigFile(path string) Option {
+	return func(t *Tracer) {
+		t.configFile = path
+	}
+}
+
+func WithDebugHeader(header string, authorizer func(ctx context.Context) bool) Option {
+	return func(t *Tracer) {
+		t.DebugHeader = header
+		t.DebugAuthorizer = authorizer
+	}
+}
+
+func WithDynamicConfig(c *DynamicConfig) Option {
+	return func(t *Tracer) {
+		t.DynamicConfig = c
+	}
+}
+
+func WithExtensionPropagation(propagator propagation.TextMapPropagator) Option {
+	return func(t *Tracer) {
+		t.ExtensionPropagation = true
+		t.Propagator = propagator
+	}
+}
+
+func WithFieldArguments() Option {
+	return func(t *Tracer) {
+		t.IncludeFieldArguments = true
+	}
+}
+
+func WithFieldAttributes(f func(ctx context.Context, fc *graphql.FieldContext) []attribute.KeyValue) Option {
+	return func(t *Tracer) {
+		t.FieldAttributes = f
+	}
+}
+
+func WithFieldResults() Option {
+	return func(t *Tracer) {
+		t.IncludeFieldResults = true
+	}
+}
+
+func WithFieldSpans() Option {
+	return func(t *Tracer) {
+		t.IncludeFieldSpans = true
+	}
+}
+
+func WithHTTPSpanMode(mode HTTPSpanMode, route string) Option {
+	return func(t *Tracer) {
+		t.HTTPSpanMode = mode
+		t.HTTPRoute = route
+	}
+}
+
+func WithInheritTracerProvider() Option {
+	return func(t *Tracer) {
+		t.InheritTracerProvider = true
+	}
+}
+
+func WithMeterProvider(mp metric.MeterProvider) Option {
+	return func(t *Tracer) {
+		t.MeterProvider = mp
+	}
+}
+
+func WithMetricClientName(f func(name string) string) Option {
+	return func(t *Tracer) {
+		t.MetricClientName = f
+	}
+}
+
+func WithMetricOperationName(f func(name string) string) Option {
+	return func(t *Tracer) {
+		t.MetricOperationName = f
+	}
+}
+
+func WithOnOperationEnd(f func(ctx context.Context, span trace.Span, res *graphql.Response)) Option {
+	return func(t *Tracer) {
+		t.OnOperationEnd = f
+	}
+}
+
+func WithOperationAttributes(f func(ctx context.Context, oc *graphql.OperationContext) []attribute.KeyValue) Option {
+	return func(t *Tracer) {
+		t.OperationAttributes = f
+	}
+}
+
+func WithoutDocument() Option {
+	return func(t *Tracer) {
+		t.OmitDocument = true
+	}
+}
+
+func WithSuspiciousThresholds(thresholds SuspiciousThresholds) Option {
+	return func(t *Tracer) {
+		t.SuspiciousThresholds = thresholds
+	}
+}
+
+func WithTraceIDExtension(includeInErrors bool) Option {
+	return func(t *Tracer) {
+		t.IncludeTraceIDExtension = true
+		t.IncludeTraceIDInErrors = includeInErrors
+	}
+}
+
+func WithTraceTree() Option {
+	return func(t *Tracer) {
+		t.IncludeTraceTree = true
+	}
+}
+
+func WithTracerProvider(tp trace.TracerProvider) Option {
+	return func(t *Tracer) {
+		t.TracerProvider = tp
+	}
+}
+
+func WithUsageCollector(c *UsageCollector) Option {
+	return func(t *Tracer) {
+		t.UsageCollector = c
+	}
+}
+
+func WithVariables() Option {
+	return func(t *Tracer) {
+		t.IncludeVariables = true
+	}
+}
+
+func (t Tracer) validateConfig() error {
+	var errs []error
+	if t.IncludeFieldArguments && !t.IncludeFieldSpans {
+		errs = append(errs, errors.New("IncludeFieldArguments requires IncludeFieldSpans"))
+	}
+	if t.IncludeFieldResults && !t.IncludeFieldSpans {
+		errs = append(errs, errors.New("IncludeFieldResults requires IncludeFieldSpans"))
+	}
+	switch t.HTTPSpanMode {
+	case HTTPSpanNested:
+		if t.HTTPRoute != "" {
+			errs = append(errs, errors.New("HTTPRoute requires HTTPSpanMode to be HTTPSpanReuse or HTTPSpanChild"))
+		}
+	case HTTPSpanReuse, HTTPSpanChild:
+	default:
+		errs = append(errs, errors.New("HTTPSpanMode is not a valid mode"))
+	}
+	if t.SuspiciousThresholds.AliasCount < 0 ||
+		t.SuspiciousThresholds.Depth < 0 ||
+		t.SuspiciousThresholds.DirectiveCount < 0 ||
+		t.SuspiciousThresholds.DuplicateFieldCount < 0 ||
+		t.SuspiciousThresholds.FragmentSpreadCount < 0 {
+		errs = append(errs, errors.New("SuspiciousThresholds can not be negative"))
+	}
+	return errors.Join(errs...)
+}
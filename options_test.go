@@ -0,0 +1,113 @@
+package gqlgen_opentelemetry
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/zhevron/gqlgen-opentelemetry/v2/testserver"
+	"github.com/zhevron/gqlgen-opentelemetry/v2/testserver/generated"
+	sdkmetric "go.opentelemetry.io/otel/sdk/metric"
+	"go.opentelemetry.io/otel/sdk/metric/metricdata"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+)
+
+func TestNew(t *testing.T) {
+	tp := sdktrace.NewTracerProvider()
+	mp := sdkmetric.NewMeterProvider()
+	collector := NewUsageCollector()
+
+	tracer := New(
+		WithClientHeaders("x-client-name", "x-client-version"),
+		WithFieldArguments(),
+		WithFieldResults(),
+		WithFieldSpans(),
+		WithHTTPSpanMode(HTTPSpanReuse, "/graphql"),
+		WithMeterProvider(mp),
+		WithSuspiciousThresholds(SuspiciousThresholds{AliasCount: 10}),
+		WithTracerProvider(tp),
+		WithUsageCollector(collector),
+		WithVariables(),
+	)
+	assert.Equal(t, "x-client-name", tracer.ClientNameHeader)
+	assert.Equal(t, "x-client-version", tracer.ClientVersionHeader)
+	assert.True(t, tracer.IncludeFieldArguments)
+	assert.True(t, tracer.IncludeFieldResults)
+	assert.True(t, tracer.IncludeFieldSpans)
+	assert.True(t, tracer.IncludeVariables)
+	assert.Equal(t, HTTPSpanReuse, tracer.HTTPSpanMode)
+	assert.Equal(t, "/graphql", tracer.HTTPRoute)
+	assert.Equal(t, mp, tracer.MeterProvider)
+	assert.Equal(t, 10, tracer.SuspiciousThresholds.AliasCount)
+	assert.Equal(t, tp, tracer.TracerProvider)
+	assert.Same(t, collector, tracer.UsageCollector)
+
+	require.NotNil(t, tracer.cache)
+	_, ok := tracer.cache.tracers.Load(tp)
+	assert.False(t, ok)
+	assert.Nil(t, tracer.cache.instruments.Load())
+
+	schema := generated.NewExecutableSchema(generated.Config{
+		Resolvers: &testserver.Resolver{},
+	})
+	require.NoError(t, tracer.Validate(schema))
+	_, ok = tracer.cache.tracers.Load(tp)
+	assert.True(t, ok)
+	require.NotNil(t, tracer.cache.instruments.Load())
+	assert.Equal(t, mp, tracer.cache.instruments.Load().provider)
+}
+
+func TestNew_FieldsChangedAfterNew(t *testing.T) {
+	exporter := tracetest.NewInMemoryExporter()
+	tp := sdktrace.NewTracerProvider(sdktrace.WithSyncer(exporter))
+	reader := sdkmetric.NewManualReader()
+	mp := sdkmetric.NewMeterProvider(sdkmetric.WithReader(reader))
+
+	tracer := New(
+		WithMeterProvider(sdkmetric.NewMeterProvider()),
+		WithTracerProvider(sdktrace.NewTracerProvider()),
+	)
+	schema := generated.NewExecutableSchema(generated.Config{
+		Resolvers: &testserver.Resolver{},
+	})
+	require.NoError(t, tracer.Validate(schema))
+	tracer.MeterProvider = mp
+	tracer.TracerProvider = tp
+
+	_, span := tracer.getTracer(context.Background()).Start(context.Background(), "test")
+	span.End()
+	assert.Len(t, exporter.GetSpans(), 1)
+
+	tracer.getInstruments().aliasCount.Record(context.Background(), 1)
+	var rm metricdata.ResourceMetrics
+	require.NoError(t, reader.Collect(context.Background(), &rm))
+	assert.Len(t, rm.ScopeMetrics, 1)
+}
+
+func TestNew_Defaults(t *testing.T) {
+	tracer := New()
+	assert.False(t, tracer.IncludeFieldSpans)
+	assert.False(t, tracer.IncludeVariables)
+	assert.Nil(t, tracer.TracerProvider)
+	require.NotNil(t, tracer.cache)
+	tracer.cache.tracers.Range(func(key, value interface{}) bool {
+		t.Errorf("unexpected cached tracer for %T", key)
+		return true
+	})
+}
+
+func TestTracer_Validate(t *testing.T) {
+	schema := generated.NewExecutableSchema(generated.Config{
+		Resolvers: &testserver.Resolver{},
+	})
+
+	assert.NoError(t, Tracer{}.Validate(schema))
+	assert.NoError(t, New(WithFieldSpans(), WithFieldArguments()).Validate(schema))
+	assert.ErrorContains(t, Tracer{IncludeFieldArguments: true}.Validate(schema), "IncludeFieldArguments requires IncludeFieldSpans")
+	assert.ErrorContains(t, Tracer{IncludeFieldResults: true}.Validate(schema), "IncludeFieldResults requires IncludeFieldSpans")
+	assert.ErrorContains(t, Tracer{HTTPRoute: "/graphql"}.Validate(schema), "HTTPRoute requires HTTPSpanMode")
+	assert.ErrorContains(t, Tracer{HTTPSpanMode: HTTPSpanMode(10)}.Validate(schema), "HTTPSpanMode is not a valid mode")
+	assert.ErrorContains(t, Tracer{SuspiciousThresholds: SuspiciousThresholds{Depth: -1}}.Validate(schema), "SuspiciousThresholds can not be negative")
+}
@@ -0,0 +1,42 @@
+package gqlgen_opentelemetry
+
+import (
+	"context"
+
+	"github.com/99designs/gqlgen/graphql"
+	"go.opentelemetry.io/otel/attribute"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	semconv "go.opentelemetry.io/otel/semconv/v1.38.0"
+)
+
+type SpanProcessor struct{}
+
+func (SpanProcessor) OnStart(parent context.Context, s sdktrace.ReadWriteSpan) {
+	if s.InstrumentationScope().Name == extensionName || !graphql.HasOperationContext(parent) {
+		return
+	}
+	oc := graphql.GetOperationContext(parent)
+	var attrs []attribute.KeyValue
+	if oc.Operation != nil {
+		attrs = append(attrs, getOperationTypeAttribute(oc))
+	}
+	if operationName := getOperationName(oc); operationName != "" {
+		attrs = append(attrs, semconv.GraphQLOperationName(operationName))
+	}
+	if fc := graphql.GetFieldContext(parent); fc != nil && fc.Field.Field != nil && fc.Field.ObjectDefinition != nil {
+		attrs = append(attrs, graphqlSchemaCoordinate.String(fieldCoordinate(fc.Field.ObjectDefinition.Name, fc.Field.Name)))
+	}
+	s.SetAttributes(attrs...)
+}
+
+func (SpanProcessor) OnEnd(sdktrace.ReadOnlySpan) {}
+
+func (SpanProcessor) Shutdown(context.Context) error {
+	return nil
+}
+
+func (SpanProcessor) ForceFlush(context.Context) error {
+	return nil
+}
+
+var _ sdktrace.SpanProcessor = SpanProcessor{}
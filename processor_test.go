@@ -0,0 +1,26 @@
+package gqlgen_opentelemetry
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+)
+
+func TestSpanProcessor_WithoutOperation(t *testing.T) {
+	exporter := tracetest.NewInMemoryExporter()
+	tp := sdktrace.NewTracerProvider(
+		sdktrace.WithSpanProcessor(SpanProcessor{}),
+		sdktrace.WithSyncer(exporter),
+	)
+
+	_, span := tp.Tracer("test").Start(context.Background(), "test")
+	span.End()
+
+	spans := exporter.GetSpans()
+	if assert.Len(t, spans, 1) {
+		assert.Empty(t, spans[0].Attributes)
+	}
+}
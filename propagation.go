@@ -0,0 +1,40 @@
+package gqlgen_opentelemetry
+
+import (
+	"context"
+
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/propagation"
+	"go.opentelemetry.io/otel/trace"
+)
+
+func (t Tracer) getPropagator() propagation.TextMapPropagator {
+	if t.Propagator != nil {
+		return t.Propagator
+	}
+	return otel.GetTextMapPropagator()
+}
+
+func makeExtensionsCarrier(extensions map[string]interface{}, fields []string) propagation.MapCarrier {
+	carrier := propagation.MapCarrier{}
+	for _, field := range fields {
+		if value, ok := extensions[field].(string); ok && value != "" {
+			carrier[field] = value
+		}
+	}
+	return carrier
+}
+
+func (t Tracer) extractExtensions(ctx context.Context, extensions map[string]interface{}) (context.Context, []trace.Link) {
+	propagator := t.getPropagator()
+	carrier := makeExtensionsCarrier(extensions, propagator.Fields())
+	if len(carrier) == 0 {
+		return ctx, nil
+	}
+	current := trace.SpanContextFromContext(ctx)
+	ctx = propagator.Extract(ctx, carrier)
+	if extracted := trace.SpanContextFromContext(ctx); current.IsValid() && !extracted.Equal(current) {
+		return ctx, []trace.Link{{SpanContext: current}}
+	}
+	return ctx, nil
+}
@@ -0,0 +1,39 @@
+package gqlgen_opentelemetry
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"go.opentelemetry.io/otel/propagation"
+	"go.opentelemetry.io/otel/trace"
+)
+
+func TestMakeExtensionsCarrier(t *testing.T) {
+	carrier := makeExtensionsCarrier(map[string]interface{}{
+		"traceparent": "00-0af7651916cd43dd8448eb211c80319c-b7ad6b7169203331-01",
+		"tracestate":  1,
+		"baggage":     "",
+		"other":       "value",
+	}, []string{"traceparent", "tracestate", "baggage"})
+
+	assert.Equal(t, propagation.MapCarrier{
+		"traceparent": "00-0af7651916cd43dd8448eb211c80319c-b7ad6b7169203331-01",
+	}, carrier)
+}
+
+func TestExtractExtensions(t *testing.T) {
+	tracer := Tracer{Propagator: propagation.TraceContext{}}
+
+	ctx, links := tracer.extractExtensions(context.Background(), nil)
+	assert.False(t, trace.SpanContextFromContext(ctx).IsValid())
+	assert.Empty(t, links)
+
+	ctx, links = tracer.extractExtensions(context.Background(), map[string]interface{}{
+		"traceparent": "00-0af7651916cd43dd8448eb211c80319c-b7ad6b7169203331-01",
+	})
+	sc := trace.SpanContextFromContext(ctx)
+	assert.True(t, sc.IsRemote())
+	assert.Equal(t, "0af7651916cd43dd8448eb211c80319c", sc.TraceID().String())
+	assert.Empty(t, links)
+}
@@ -0,0 +1,56 @@
+package gqlgen_opentelemetry
+
+import (
+	"reflect"
+	"slices"
+
+	"github.com/99designs/gqlgen/graphql"
+	"github.com/vektah/gqlparser/v2/ast"
+	"go.opentelemetry.io/otel/attribute"
+)
+
+func makeResultAttributes(fc *graphql.FieldContext, result interface{}, schema *ast.Schema) []attribute.KeyValue {
+	v := reflect.ValueOf(result)
+	for v.IsValid() && (v.Kind() == reflect.Pointer || v.Kind() == reflect.Interface) && !v.IsNil() {
+		v = v.Elem()
+	}
+	if !v.IsValid() || isNilValue(v) {
+		return []attribute.KeyValue{graphqlFieldResultNull.Bool(true)}
+	}
+	attrs := []attribute.KeyValue{graphqlFieldResultNull.Bool(false)}
+	if v.Kind() == reflect.Slice || v.Kind() == reflect.Array {
+		attrs = append(attrs, graphqlFieldResultCount.Int(v.Len()))
+	} else if typeName := getConcreteTypeName(fc, v.Type(), schema); typeName != "" {
+		attrs = append(attrs, graphqlFieldResultTypename.String(typeName))
+	}
+	return attrs
+}
+
+func isNilValue(v reflect.Value) bool {
+	switch v.Kind() {
+	case reflect.Chan, reflect.Func, reflect.Interface, reflect.Map, reflect.Pointer, reflect.Slice:
+		return v.IsNil()
+	default:
+		return false
+	}
+}
+
+// getConcreteTypeName is best-effort: it assumes the Go type of the result has
+// the same name as the GraphQL type it is bound to, and returns "" when it does
+// not.
+func getConcreteTypeName(fc *graphql.FieldContext, t reflect.Type, schema *ast.Schema) string {
+	if schema == nil || fc.Field.Definition == nil || fc.Field.Definition.Type == nil {
+		return ""
+	}
+	def := schema.Types[fc.Field.Definition.Type.Name()]
+	if def == nil || !def.IsAbstractType() {
+		return ""
+	}
+	possibleTypes := schema.GetPossibleTypes(def)
+	if slices.ContainsFunc(possibleTypes, func(possibleType *ast.Definition) bool {
+		return possibleType.Name == t.Name()
+	}) {
+		return t.Name()
+	}
+	return ""
+}
@@ -0,0 +1,45 @@
+package gqlgen_opentelemetry
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/99designs/gqlgen/graphql"
+	"github.com/stretchr/testify/assert"
+	"github.com/vektah/gqlparser/v2/ast"
+	"github.com/zhevron/gqlgen-opentelemetry/v2/testserver"
+	"github.com/zhevron/gqlgen-opentelemetry/v2/testserver/generated"
+	"github.com/zhevron/gqlgen-opentelemetry/v2/testserver/model"
+)
+
+type boundPerson struct {
+	ID   string
+	Name string
+}
+
+func (boundPerson) IsNode()         {}
+func (p boundPerson) GetID() string { return p.ID }
+
+func TestGetConcreteTypeName(t *testing.T) {
+	schema := generated.NewExecutableSchema(generated.Config{
+		Resolvers: &testserver.Resolver{},
+	}).Schema()
+	fc := &graphql.FieldContext{Field: graphql.CollectedField{Field: &ast.Field{
+		Definition: schema.Query.Fields.ForName("node"),
+	}}}
+
+	assert.Equal(t, "Person", getConcreteTypeName(fc, reflect.TypeOf(model.Person{}), schema))
+	assert.Equal(t, "", getConcreteTypeName(fc, reflect.TypeOf(model.Person{}), nil))
+}
+
+func TestGetConcreteTypeName_BoundModel(t *testing.T) {
+	schema := generated.NewExecutableSchema(generated.Config{
+		Resolvers: &testserver.Resolver{},
+	}).Schema()
+	fc := &graphql.FieldContext{Field: graphql.CollectedField{Field: &ast.Field{
+		Definition: schema.Query.Fields.ForName("node"),
+	}}}
+
+	// A model bound to Person under another Go name is not recognised.
+	assert.Equal(t, "", getConcreteTypeName(fc, reflect.TypeOf(boundPerson{}), schema))
+}
@@ -0,0 +1,24 @@
+package gqlgen_opentelemetry
+
+import (
+	"github.com/vektah/gqlparser/v2/ast"
+)
+
+func (t Tracer) getSchema() *ast.Schema {
+	if t.cache == nil {
+		return nil
+	}
+	return t.cache.schema.Load()
+}
+
+func isDeprecated(directives ast.DirectiveList) bool {
+	return directives.ForName("deprecated") != nil
+}
+
+func fieldCoordinate(typeName, fieldName string) string {
+	return typeName + "." + fieldName
+}
+
+func argumentCoordinate(typeName, fieldName, argName string) string {
+	return typeName + "." + fieldName + "(" + argName + ":)"
+}
@@ -0,0 +1,31 @@
+package gqlgen_opentelemetry
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/zhevron/gqlgen-opentelemetry/v2/testserver"
+	"github.com/zhevron/gqlgen-opentelemetry/v2/testserver/generated"
+)
+
+func TestTracer_GetSchema(t *testing.T) {
+	schema := generated.NewExecutableSchema(generated.Config{
+		Resolvers: &testserver.Resolver{},
+	})
+
+	tracer := New()
+	assert.Nil(t, tracer.getSchema())
+	require.Nil(t, tracer.Validate(schema))
+	assert.Same(t, schema.Schema(), tracer.getSchema())
+}
+
+func TestTracer_GetSchema_WithoutNew(t *testing.T) {
+	schema := generated.NewExecutableSchema(generated.Config{
+		Resolvers: &testserver.Resolver{},
+	})
+
+	tracer := Tracer{}
+	require.Nil(t, tracer.Validate(schema))
+	assert.Nil(t, tracer.getSchema())
+}
@@ -0,0 +1,160 @@
+package gqlgen_opentelemetry
+
+import (
+	"bufio"
+	"context"
+	"fmt"
+	"mime"
+	"net"
+	"net/http"
+	"slices"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/99designs/gqlgen/graphql"
+)
+
+const serverTimingResolvers = 5
+
+type serverTimingKey struct{}
+
+type serverTiming struct {
+	mu        sync.Mutex
+	parse     time.Duration
+	validate  time.Duration
+	execute   time.Duration
+	resolvers []resolverTiming // slowest first, at most serverTimingResolvers
+}
+
+type resolverTiming struct {
+	path     string
+	duration time.Duration
+}
+
+func ServerTiming(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		timing := &serverTiming{}
+		ctx := context.WithValue(r.Context(), serverTimingKey{}, timing)
+		next.ServeHTTP(&serverTimingWriter{ResponseWriter: w, timing: timing}, r.WithContext(ctx))
+	})
+}
+
+func getServerTiming(ctx context.Context) *serverTiming {
+	timing, _ := ctx.Value(serverTimingKey{}).(*serverTiming)
+	return timing
+}
+
+func (s *serverTiming) recordOperation(oc *graphql.OperationContext) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.parse += durationOf(oc.Stats.Parsing)
+	s.validate += durationOf(oc.Stats.Validation)
+}
+
+func (s *serverTiming) recordExecute(execute time.Duration) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.execute += execute
+}
+
+func (s *serverTiming) recordResolver(fc *graphql.FieldContext, duration time.Duration) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if len(s.resolvers) == serverTimingResolvers && duration <= s.resolvers[len(s.resolvers)-1].duration {
+		return
+	}
+	i, _ := slices.BinarySearchFunc(s.resolvers, duration, func(r resolverTiming, d time.Duration) int {
+		if r.duration >= d {
+			return -1
+		}
+		return 1
+	})
+	s.resolvers = slices.Insert(s.resolvers, i, resolverTiming{
+		path:     fc.Path().String(),
+		duration: duration,
+	})
+	if len(s.resolvers) > serverTimingResolvers {
+		s.resolvers = s.resolvers[:serverTimingResolvers]
+	}
+}
+
+func (s *serverTiming) header() string {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	metrics := []string{
+		formatServerTiming("gql-parse", "", s.parse),
+		formatServerTiming("gql-validate", "", s.validate),
+		formatServerTiming("gql-execute", "", s.execute),
+	}
+	for i, resolver := range s.resolvers {
+		metrics = append(metrics, formatServerTiming(fmt.Sprintf("gql-resolver-%d", i+1), resolver.path, resolver.duration))
+	}
+	return strings.Join(metrics, ", ")
+}
+
+func formatServerTiming(name, desc string, duration time.Duration) string {
+	metric := name
+	if desc != "" {
+		metric += fmt.Sprintf(";desc=%q", desc)
+	}
+	return metric + fmt.Sprintf(";dur=%.3f", float64(duration)/float64(time.Millisecond))
+}
+
+func durationOf(timing graphql.TraceTiming) time.Duration {
+	if timing.Start.IsZero() || timing.End.IsZero() {
+		return 0
+	}
+	return timing.End.Sub(timing.Start)
+}
+
+// isStreamingResponse reports whether the headers are sent before the operation
+// is executed, as is done for server-sent events and multipart/mixed responses.
+func isStreamingResponse(header http.Header) bool {
+	mediaType, _, _ := mime.ParseMediaType(header.Get("Content-Type"))
+	return mediaType == "text/event-stream" || mediaType == "multipart/mixed"
+}
+
+type serverTimingWriter struct {
+	http.ResponseWriter
+	timing      *serverTiming
+	wroteHeader bool
+}
+
+func (w *serverTimingWriter) WriteHeader(statusCode int) {
+	if !w.wroteHeader {
+		w.wroteHeader = true
+		if !isStreamingResponse(w.Header()) {
+			w.Header().Set("Server-Timing", w.timing.header())
+		}
+	}
+	w.ResponseWriter.WriteHeader(statusCode)
+}
+
+func (w *serverTimingWriter) Write(b []byte) (int, error) {
+	if !w.wroteHeader {
+		w.WriteHeader(http.StatusOK)
+	}
+	return w.ResponseWriter.Write(b)
+}
+
+func (w *serverTimingWriter) Flush() {
+	if !w.wroteHeader {
+		w.WriteHeader(http.StatusOK)
+	}
+	if f, ok := w.ResponseWriter.(http.Flusher); ok {
+		f.Flush()
+	}
+}
+
+func (w *serverTimingWriter) Unwrap() http.ResponseWriter {
+	return w.ResponseWriter
+}
+
+func (w *serverTimingWriter) Hijack() (net.Conn, *bufio.ReadWriter, error) {
+	h, ok := w.ResponseWriter.(http.Hijacker)
+	if !ok {
+		return nil, nil, http.ErrNotSupported
+	}
+	return h.Hijack()
+}
@@ -0,0 +1,108 @@
+package gqlgen_opentelemetry
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/99designs/gqlgen/graphql"
+	"github.com/stretchr/testify/assert"
+	"github.com/vektah/gqlparser/v2/ast"
+)
+
+func TestServerTiming_Header(t *testing.T) {
+	timing := &serverTiming{
+		parse:    time.Millisecond,
+		validate: 2 * time.Millisecond,
+		execute:  3500 * time.Microsecond,
+	}
+	for _, i := range []int{3, 1, 7, 4, 2, 6, 5} {
+		timing.recordResolver(newTestFieldContext("field"+strconv.Itoa(i)), time.Duration(i)*time.Millisecond)
+	}
+	assert.Len(t, timing.resolvers, serverTimingResolvers)
+
+	assert.Equal(t, strings.Join([]string{
+		"gql-parse;dur=1.000",
+		"gql-validate;dur=2.000",
+		"gql-execute;dur=3.500",
+		`gql-resolver-1;desc="field7";dur=7.000`,
+		`gql-resolver-2;desc="field6";dur=6.000`,
+		`gql-resolver-3;desc="field5";dur=5.000`,
+		`gql-resolver-4;desc="field4";dur=4.000`,
+		`gql-resolver-5;desc="field3";dur=3.000`,
+	}, ", "), timing.header())
+}
+
+func TestServerTiming_Handler(t *testing.T) {
+	var timing *serverTiming
+	h := ServerTiming(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		timing = getServerTiming(r.Context())
+		timing.execute = time.Millisecond
+		_, _ = w.Write([]byte("{}"))
+	}))
+
+	rec := httptest.NewRecorder()
+	h.ServeHTTP(rec, httptest.NewRequest(http.MethodPost, "/", nil))
+	assert.NotNil(t, timing)
+	assert.Equal(t, http.StatusOK, rec.Code)
+	assert.Contains(t, rec.Header().Get("Server-Timing"), "gql-execute;dur=1.000")
+}
+
+func TestServerTiming_Streaming(t *testing.T) {
+	for _, contentType := range []string{"text/event-stream", `multipart/mixed; boundary="-"`} {
+		h := ServerTiming(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			w.Header().Set("Content-Type", contentType)
+			w.(http.Flusher).Flush()
+			getServerTiming(r.Context()).recordExecute(time.Millisecond)
+		}))
+
+		rec := httptest.NewRecorder()
+		h.ServeHTTP(rec, httptest.NewRequest(http.MethodPost, "/", nil))
+		assert.Equal(t, http.StatusOK, rec.Code)
+		assert.Empty(t, rec.Header().Get("Server-Timing"), contentType)
+	}
+}
+
+func TestServerTiming_RecordOperation(t *testing.T) {
+	start := time.Now()
+	oc := &graphql.OperationContext{}
+	oc.Stats.Parsing = graphql.TraceTiming{Start: start, End: start.Add(time.Millisecond)}
+	oc.Stats.Validation = graphql.TraceTiming{Start: start, End: start.Add(2 * time.Millisecond)}
+
+	timing := &serverTiming{}
+	timing.recordOperation(oc)
+	timing.recordExecute(time.Millisecond)
+	timing.recordExecute(time.Millisecond)
+
+	assert.Equal(t, time.Millisecond, timing.parse)
+	assert.Equal(t, 2*time.Millisecond, timing.validate)
+	assert.Equal(t, 2*time.Millisecond, timing.execute)
+}
+
+func TestServerTiming_RecordResolver_Ties(t *testing.T) {
+	timing := &serverTiming{}
+	for i := 1; i <= 7; i++ {
+		timing.recordResolver(newTestFieldContext("field"+strconv.Itoa(i)), time.Millisecond)
+	}
+
+	var paths []string
+	for _, resolver := range timing.resolvers {
+		paths = append(paths, resolver.path)
+	}
+	assert.Equal(t, []string{"field1", "field2", "field3", "field4", "field5"}, paths)
+}
+
+func TestServerTiming_Hijack(t *testing.T) {
+	h := ServerTiming(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		_, _, err := w.(http.Hijacker).Hijack()
+		assert.ErrorIs(t, err, http.ErrNotSupported)
+	}))
+	h.ServeHTTP(httptest.NewRecorder(), httptest.NewRequest(http.MethodGet, "/", nil))
+}
+
+func newTestFieldContext(alias string) *graphql.FieldContext {
+	return &graphql.FieldContext{Field: graphql.CollectedField{Field: &ast.Field{Alias: alias}}}
+}
@@ -0,0 +1,34 @@
+package gqlgen_opentelemetry
+
+import (
+	"context"
+
+	"go.opentelemetry.io/otel/trace"
+)
+
+type (
+	fieldSpanKey     struct{}
+	operationSpanKey struct{}
+)
+
+func OperationSpan(ctx context.Context) trace.Span {
+	if span, ok := ctx.Value(operationSpanKey{}).(trace.Span); ok {
+		return span
+	}
+	return trace.SpanFromContext(context.Background())
+}
+
+func FieldSpan(ctx context.Context) trace.Span {
+	if span, ok := ctx.Value(fieldSpanKey{}).(trace.Span); ok {
+		return span
+	}
+	return OperationSpan(ctx)
+}
+
+func withOperationSpan(ctx context.Context, span trace.Span) context.Context {
+	return context.WithValue(ctx, operationSpanKey{}, span)
+}
+
+func withFieldSpan(ctx context.Context, span trace.Span) context.Context {
+	return context.WithValue(ctx, fieldSpanKey{}, span)
+}
@@ -0,0 +1,33 @@
+package gqlgen_opentelemetry
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+)
+
+func TestOperationSpan(t *testing.T) {
+	tp := sdktrace.NewTracerProvider()
+	_, span := tp.Tracer("test").Start(context.Background(), "operation")
+	ctx := withOperationSpan(context.Background(), span)
+
+	assert.Equal(t, span, OperationSpan(ctx))
+	assert.Equal(t, span, FieldSpan(ctx))
+}
+
+func TestFieldSpan(t *testing.T) {
+	tp := sdktrace.NewTracerProvider()
+	_, operationSpan := tp.Tracer("test").Start(context.Background(), "operation")
+	_, fieldSpan := tp.Tracer("test").Start(context.Background(), "field")
+	ctx := withFieldSpan(withOperationSpan(context.Background(), operationSpan), fieldSpan)
+
+	assert.Equal(t, operationSpan, OperationSpan(ctx))
+	assert.Equal(t, fieldSpan, FieldSpan(ctx))
+}
+
+func TestOperationSpan_Missing(t *testing.T) {
+	assert.False(t, OperationSpan(context.Background()).SpanContext().IsValid())
+	assert.False(t, FieldSpan(context.Background()).SpanContext().IsValid())
+}
@@ -34,6 +34,7 @@ type Config struct {
 type ResolverRoot interface {
 	Mutation() MutationResolver
 	Query() QueryResolver
+	Subscription() SubscriptionResolver
 }
 
 type DirectiveRoot struct {
@@ -65,7 +66,9 @@ func (e *executableSchema) Complexity(ctx context.Context, typeName, field strin
 func (e *executableSchema) Exec(ctx context.Context) graphql.ResponseHandler {
 	opCtx := graphql.GetOperationContext(ctx)
 	ec := executionContext{opCtx, e, 0, 0, make(chan graphql.DeferredResult)}
-	inputUnmarshalMap := graphql.BuildUnmarshalerMap()
+	inputUnmarshalMap := graphql.BuildUnmarshalerMap(
+		ec.unmarshalInputGreetingInput,
+	)
 	first := true
 
 	switch opCtx.Operation.Operation {
@@ -110,6 +113,23 @@ func (e *executableSchema) Exec(ctx context.Context) graphql.ResponseHandler {
 			var buf bytes.Buffer
 			data.MarshalGQL(&buf)
 
+			return &graphql.Response{
+				Data: buf.Bytes(),
+			}
+		}
+	case ast.Subscription:
+		next := ec._Subscription(ctx, opCtx.Operation.SelectionSet)
+
+		var buf bytes.Buffer
+		return func(ctx context.Context) *graphql.Response {
+			buf.Reset()
+			data := next(ctx)
+
+			if data == nil {
+				return nil
+			}
+			data.MarshalGQL(&buf)
+
 			return &graphql.Response{
 				Data: buf.Bytes(),
 			}
@@ -165,15 +185,47 @@ var sources = []*ast.Source{
 	{Name: "../schema.graphql", Input: `schema {
     query: Query
     mutation: Mutation
+    subscription: Subscription
+}
+
+enum Language {
+    DANISH
+    ENGLISH
+    OLD_NORSE @deprecated(reason: "No longer spoken.")
+}
+
+input GreetingInput {
+    language: Language
+    name: String!
+    title: String @deprecated(reason: "Titles are no longer shown.")
+}
+
+interface Node {
+    id: ID!
+}
+
+type Person implements Node {
+    id: ID!
+    name: String!
 }
 
 type Query {
+    customGreeting(input: GreetingInput!): String!
     greeting: String!
+    greetings: [String!]!
+    hello: String! @deprecated(reason: "Use greeting instead.")
+    localizedGreeting(language: Language!, formal: Boolean @deprecated(reason: "All greetings are informal.")): String!
+    node(id: ID!): Node
 }
 
 type Mutation {
     greet(name: String!): String!
 }
+
+type Subscription {
+    greetings: String!
+    hello: String! @deprecated(reason: "Use greetings instead.")
+}
 `, BuiltIn: false},
 }
 var parsedSchema = gqlparser.MustLoadSchema(sources...)
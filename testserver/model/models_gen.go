@@ -0,0 +1,95 @@
+// Code generated by github.com/99designs/gqlgen, DO NOT EDIT.
+
+package model
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"strconv"
+)
+
+type Node interface {
+	IsNode()
+	GetID() string
+}
+
+type GreetingInput struct {
+	Language *Language `json:"language,omitempty"`
+	Name     string    `json:"name"`
+	Title    *string   `json:"title,omitempty"`
+}
+
+type Mutation struct {
+}
+
+type Person struct {
+	ID   string `json:"id"`
+	Name string `json:"name"`
+}
+
+func (Person) IsNode()            {}
+func (this Person) GetID() string { return this.ID }
+
+type Query struct {
+}
+
+type Subscription struct {
+}
+
+type Language string
+
+const (
+	LanguageDanish   Language = "DANISH"
+	LanguageEnglish  Language = "ENGLISH"
+	LanguageOldNorse Language = "OLD_NORSE"
+)
+
+var AllLanguage = []Language{
+	LanguageDanish,
+	LanguageEnglish,
+	LanguageOldNorse,
+}
+
+func (e Language) IsValid() bool {
+	switch e {
+	case LanguageDanish, LanguageEnglish, LanguageOldNorse:
+		return true
+	}
+	return false
+}
+
+func (e Language) String() string {
+	return string(e)
+}
+
+func (e *Language) UnmarshalGQL(v any) error {
+	str, ok := v.(string)
+	if !ok {
+		return fmt.Errorf("enums must be strings")
+	}
+
+	*e = Language(str)
+	if !e.IsValid() {
+		return fmt.Errorf("%s is not a valid Language", str)
+	}
+	return nil
+}
+
+func (e Language) MarshalGQL(w io.Writer) {
+	fmt.Fprint(w, strconv.Quote(e.String()))
+}
+
+func (e *Language) UnmarshalJSON(b []byte) error {
+	s, err := strconv.Unquote(string(b))
+	if err != nil {
+		return err
+	}
+	return e.UnmarshalGQL(s)
+}
+
+func (e Language) MarshalJSON() ([]byte, error) {
+	var buf bytes.Buffer
+	e.MarshalGQL(&buf)
+	return buf.Bytes(), nil
+}
@@ -1,9 +0,0 @@
-// Code generated by github.com/99designs/gqlgen, DO NOT EDIT.
-
-package testserver
-
-type Mutation struct {
-}
-
-type Query struct {
-}
@@ -4,29 +4,101 @@ package testserver
 
 import (
 	"context"
+	"errors"
 
 	"github.com/zhevron/gqlgen-opentelemetry/v2/testserver/generated"
+	"github.com/zhevron/gqlgen-opentelemetry/v2/testserver/model"
 )
 
 type Resolver struct{}
 
 // Greet is the resolver for the greet field.
 func (r *mutationResolver) Greet(ctx context.Context, name string) (string, error) {
+	if name == "" {
+		return "", errors.New("name must not be empty")
+	}
 	return "Hello " + name, nil
 }
 
+// CustomGreeting is the resolver for the customGreeting field.
+func (r *queryResolver) CustomGreeting(ctx context.Context, input model.GreetingInput) (string, error) {
+	if input.Title != nil {
+		return "Hello " + *input.Title + " " + input.Name, nil
+	}
+	return "Hello " + input.Name, nil
+}
+
 // Greeting is the resolver for the greeting field.
 func (r *queryResolver) Greeting(ctx context.Context) (string, error) {
 	return "Hello world", nil
 }
 
+// Greetings is the resolver for the greetings field.
+func (r *queryResolver) Greetings(ctx context.Context) ([]string, error) {
+	return []string{"Hello world", "Hej verden"}, nil
+}
+
+// Hello is the resolver for the hello field.
+func (r *queryResolver) Hello(ctx context.Context) (string, error) {
+	return r.Greeting(ctx)
+}
+
+// LocalizedGreeting is the resolver for the localizedGreeting field.
+func (r *queryResolver) LocalizedGreeting(ctx context.Context, language model.Language, formal *bool) (string, error) {
+	switch language {
+	case model.LanguageDanish:
+		return "Hej verden", nil
+	case model.LanguageOldNorse:
+		return "Heil veröld", nil
+	default:
+		return "Hello world", nil
+	}
+}
+
+// Node is the resolver for the node field.
+func (r *queryResolver) Node(ctx context.Context, id string) (model.Node, error) {
+	if id != "1" {
+		return nil, nil
+	}
+	return &model.Person{ID: id, Name: "gqlgen"}, nil
+}
+
+// Greetings is the resolver for the greetings field.
+func (r *subscriptionResolver) Greetings(ctx context.Context) (<-chan string, error) {
+	greetings, err := (&queryResolver{r.Resolver}).Greetings(ctx)
+	if err != nil {
+		return nil, err
+	}
+	ch := make(chan string)
+	go func() {
+		defer close(ch)
+		for _, greeting := range greetings {
+			select {
+			case ch <- greeting:
+			case <-ctx.Done():
+				return
+			}
+		}
+	}()
+	return ch, nil
+}
+
+// Hello is the resolver for the hello field.
+func (r *subscriptionResolver) Hello(ctx context.Context) (<-chan string, error) {
+	return r.Greetings(ctx)
+}
+
 // Mutation returns generated.MutationResolver implementation.
 func (r *Resolver) Mutation() generated.MutationResolver { return &mutationResolver{r} }
 
 // Query returns generated.QueryResolver implementation.
 func (r *Resolver) Query() generated.QueryResolver { return &queryResolver{r} }
 
+// Subscription returns generated.SubscriptionResolver implementation.
+func (r *Resolver) Subscription() generated.SubscriptionResolver { return &subscriptionResolver{r} }
+
 type (
-	mutationResolver struct{ *Resolver }
-	queryResolver    struct{ *Resolver }
+	mutationResolver     struct{ *Resolver }
+	queryResolver        struct{ *Resolver }
+	subscriptionResolver struct{ *Resolver }
 )
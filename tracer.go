@@ -2,6 +2,7 @@ package gqlgen_opentelemetry
 
 import (
 	"context"
+	"time"
 
 	"github.com/99designs/gqlgen/graphql"
 	"github.com/99designs/gqlgen/graphql/handler/extension"
@@ -9,19 +10,52 @@ import (
 	"go.opentelemetry.io/otel"
 	"go.opentelemetry.io/otel/attribute"
 	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/metric"
+	"go.opentelemetry.io/otel/propagation"
 	semconv "go.opentelemetry.io/otel/semconv/v1.38.0"
 	"go.opentelemetry.io/otel/trace"
 )
 
 const (
-	extensionName          = "github.com/zhevron/gqlgen-opentelemetry"
-	extensionVersion       = "1.0.4"
-	graphqlComplexity      = attribute.Key("graphql.operation.complexity")
-	graphqlFieldAlias      = attribute.Key("graphql.field.alias")
-	graphqlFieldName       = attribute.Key("graphql.field.name")
-	graphqlFieldPath       = attribute.Key("graphql.field.path")
-	graphqlFieldType       = attribute.Key("graphql.field.type")
-	graphqlVariablesPrefix = "graphql.variables."
+	extensionName                        = "github.com/zhevron/gqlgen-opentelemetry"
+	extensionVersion                     = "1.0.4"
+	graphqlAliasCount                    = attribute.Key("graphql.operation.alias_count")
+	graphqlClientName                    = attribute.Key("graphql.client.name")
+	graphqlClientVersion                 = attribute.Key("graphql.client.version")
+	graphqlComplexity                    = attribute.Key("graphql.operation.complexity")
+	graphqlComplexityExceeded            = attribute.Key("graphql.operation.complexity.exceeded")
+	graphqlComplexityLimit               = attribute.Key("graphql.operation.complexity.limit")
+	graphqlDebug                         = attribute.Key("graphql.operation.debug")
+	graphqlDeprecated                    = attribute.Key("graphql.operation.deprecated")
+	graphqlDeprecatedUsage               = "graphql.deprecated.usage"
+	graphqlDepth                         = attribute.Key("graphql.operation.depth")
+	graphqlDirectiveCount                = attribute.Key("graphql.operation.directive_count")
+	graphqlDirectives                    = attribute.Key("graphql.operation.directives")
+	graphqlDuplicateFieldCount           = attribute.Key("graphql.operation.duplicate_field_count")
+	graphqlFieldAlias                    = attribute.Key("graphql.field.alias")
+	graphqlFieldArgsPrefix               = "graphql.field.args."
+	graphqlFieldCount                    = attribute.Key("graphql.operation.field_count")
+	graphqlFieldName                     = attribute.Key("graphql.field.name")
+	graphqlFieldParentType               = attribute.Key("graphql.field.parent_type")
+	graphqlFieldPath                     = attribute.Key("graphql.field.path")
+	graphqlFieldResultCount              = attribute.Key("graphql.field.result.count")
+	graphqlFieldResultNull               = attribute.Key("graphql.field.result.null")
+	graphqlFieldResultTypename           = attribute.Key("graphql.field.result.typename")
+	graphqlFieldType                     = attribute.Key("graphql.field.type")
+	graphqlFragmentSpreadCount           = attribute.Key("graphql.operation.fragment_spread_count")
+	graphqlSchemaCoordinate              = attribute.Key("graphql.schema.coordinate")
+	graphqlSuspicious                    = "graphql.suspicious"
+	graphqlSuspiciousReasons             = attribute.Key("graphql.suspicious.reasons")
+	graphqlTransport                     = attribute.Key("graphql.transport")
+	graphqlVariablesPrefix               = "graphql.variables."
+	graphqlWebsocketCloseCode            = attribute.Key("graphql.websocket.close.code")
+	graphqlWebsocketCloseReason          = attribute.Key("graphql.websocket.close.reason")
+	graphqlWebsocketConnections          = "graphql.websocket.connections"
+	graphqlWebsocketInitFailures         = "graphql.websocket.init.failures"
+	graphqlWebsocketOperation            = "graphql.websocket.operation"
+	graphqlWebsocketOperationCount       = attribute.Key("graphql.websocket.operation_count")
+	graphqlWebsocketOutgoingMessageCount = attribute.Key("graphql.websocket.outgoing_message_count")
+	graphqlWebsocketOutgoingMessages     = "graphql.websocket.outgoing_messages"
 )
 
 var baseAttributes = []attribute.KeyValue{
@@ -29,10 +63,44 @@ var baseAttributes = []attribute.KeyValue{
 	semconv.OTelScopeVersion(extensionVersion),
 }
 
+// Tracer is a gqlgen extension that records operations and fields as OTEL spans
+// and metrics. Environment variables and the config file are only read by New,
+// when it is called; a Tracer created as a struct literal ignores them.
 type Tracer struct {
-	IncludeFieldSpans bool
-	IncludeVariables  bool
-	TracerProvider    trace.TracerProvider
+	ClientBaggage           bool
+	ClientInfoFunc          func(ctx context.Context) (name, version string)
+	ClientNameHeader        string
+	ClientVersionHeader     string
+	DebugAuthorizer         func(ctx context.Context) bool
+	DebugHeader             string
+	DynamicConfig           *DynamicConfig
+	ExtensionPropagation    bool
+	FieldAttributes         func(ctx context.Context, fc *graphql.FieldContext) []attribute.KeyValue
+	HTTPRoute               string
+	HTTPSpanMode            HTTPSpanMode
+	IncludeFieldArguments   bool
+	IncludeFieldResults     bool
+	IncludeFieldSpans       bool
+	IncludeTraceIDExtension bool
+	IncludeTraceIDInErrors  bool
+	IncludeTraceTree        bool
+	IncludeVariables        bool
+	InheritTracerProvider   bool
+	MeterProvider           metric.MeterProvider
+	MetricClientName        func(name string) string
+	MetricOperationName     func(name string) string
+	OmitDocument            bool
+	OnOperationEnd          func(ctx context.Context, span trace.Span, res *graphql.Response)
+	OperationAttributes     func(ctx context.Context, oc *graphql.OperationContext) []attribute.KeyValue
+	OperationBaggage        bool
+	Propagator              propagation.TextMapPropagator
+	SuspiciousThresholds    SuspiciousThresholds
+	TracerProvider          trace.TracerProvider
+	UsageCollector          *UsageCollector
+
+	cache      *tracerCache
+	configErr  error
+	configFile string
 }
 
 func (Tracer) ExtensionName() string {
@@ -40,31 +108,122 @@ func (Tracer) ExtensionName() string {
 }
 
 func (t Tracer) Validate(schema graphql.ExecutableSchema) error {
+	if t.configErr != nil {
+		return t.configErr
+	}
+	if err := t.validateConfig(); err != nil {
+		return err
+	}
+	if t.DynamicConfig != nil {
+		t.DynamicConfig.tracer.Store(&t)
+	}
+	if t.cache != nil {
+		t.cache.schema.Store(schema.Schema())
+		t.getInstruments()
+		if t.TracerProvider != nil {
+			t.cache.getTracer(t.TracerProvider)
+		}
+	}
+	if t.UsageCollector != nil {
+		t.UsageCollector.registerSchema(schema.Schema())
+	}
 	return nil
 }
 
+func (t Tracer) InterceptOperation(ctx context.Context, next graphql.OperationHandler) graphql.ResponseHandler {
+	if !graphql.HasOperationContext(ctx) {
+		return next(ctx)
+	}
+	oc := graphql.GetOperationContext(ctx)
+	if connection := getWebsocketConnection(ctx); connection != nil {
+		attrs := []attribute.KeyValue{getOperationTypeAttribute(oc)}
+		if operationName := getOperationName(oc); operationName != "" {
+			attrs = append(attrs, semconv.GraphQLOperationName(operationName))
+		}
+		connection.recordOperation(attrs...)
+	}
+	if timing := getServerTiming(ctx); timing != nil {
+		timing.recordOperation(oc)
+	}
+	if oc.Operation != nil {
+		// Subscriptions and incremental delivery send several responses for one
+		// operation, so the operation is analysed and counted here, only once.
+		stats := getOperationStats(t.getSchema(), oc.Doc, oc.Operation, oc.Variables)
+		metricAttributes := t.makeMetricAttributes(ctx, oc)
+		instruments := t.getInstruments()
+		instruments.recordOperationStats(ctx, stats, metricAttributes...)
+		instruments.recordDeprecatedUsage(ctx, stats, metricAttributes...)
+		if t.UsageCollector != nil {
+			t.UsageCollector.record(stats.Fields, time.Now())
+		}
+		ctx = withOperationStats(ctx, &stats)
+	}
+	return next(ctx)
+}
+
 func (t Tracer) InterceptResponse(ctx context.Context, next graphql.ResponseHandler) *graphql.Response {
 	if !graphql.HasOperationContext(ctx) {
 		return next(ctx)
 	}
+	ctx = t.applyDynamicConfig(ctx)
 	oc := graphql.GetOperationContext(ctx)
-	operationName := oc.OperationName
-	if oc.Operation != nil && oc.Operation.Name != "" {
-		operationName = oc.Operation.Name
+	var links []trace.Link
+	if t.ExtensionPropagation {
+		ctx, links = t.extractExtensions(ctx, oc.Extensions)
+		ctx, links = t.extractInitPayload(ctx, links)
+	}
+	debug := t.isDebugRequest(ctx, oc)
+	if debug {
+		t.applyDebug()
+		ctx = withDebug(forceSampled(ctx))
 	}
+	operationName := getOperationName(oc)
 	operationType := getOperationTypeAttribute(oc)
 	spanName := makeSpanName(operationName, operationType.Value.AsString())
-	ctx, span := t.getTracer(ctx).Start(ctx, spanName, trace.WithSpanKind(trace.SpanKindServer), trace.WithAttributes(baseAttributes...))
-	defer span.End()
-	span.SetAttributes(
-		operationType,
-		semconv.GraphQLDocument(oc.RawQuery),
-	)
+	operationAttributes := []attribute.KeyValue{operationType}
+	if !t.OmitDocument {
+		operationAttributes = append(operationAttributes, semconv.GraphQLDocument(oc.RawQuery))
+	}
 	if operationName != "" {
-		span.SetAttributes(semconv.GraphQLOperationName(operationName))
+		operationAttributes = append(operationAttributes, semconv.GraphQLOperationName(operationName))
+	}
+	if transport := getTransport(ctx, oc.Headers); transport != "" {
+		operationAttributes = append(operationAttributes, graphqlTransport.String(transport))
+	}
+	if connection := getWebsocketConnection(ctx); connection != nil {
+		links = append(links, connection.recordOutgoingMessage())
+	}
+	ctx, span, end := t.startOperationSpan(ctx, spanName, operationAttributes, trace.WithLinks(links...))
+	defer end()
+	ctx = withOperationSpan(ctx, span)
+	if debug {
+		span.SetAttributes(graphqlDebug.Bool(true))
+	}
+	clientName, clientVersion := t.getClientInfo(ctx, oc)
+	clientAttributes := makeClientAttributes(clientName, clientVersion)
+	span.SetAttributes(clientAttributes...)
+	if t.OperationBaggage {
+		baggageAttributes := []attribute.KeyValue{operationType}
+		if operationName != "" {
+			baggageAttributes = append(baggageAttributes, semconv.GraphQLOperationName(operationName))
+		}
+		if t.ClientBaggage && clientName != "" {
+			baggageAttributes = append(baggageAttributes, graphqlClientName.String(clientName))
+		}
+		ctx = withOperationBaggage(ctx, baggageAttributes...)
 	}
 	if stats := extension.GetComplexityStats(ctx); stats != nil {
-		span.SetAttributes(graphqlComplexity.Int(stats.Complexity))
+		span.SetAttributes(
+			graphqlComplexity.Int(stats.Complexity),
+			graphqlComplexityLimit.Int(stats.ComplexityLimit),
+			graphqlComplexityExceeded.Bool(stats.Complexity > stats.ComplexityLimit),
+		)
+	}
+	if stats := getContextOperationStats(ctx); stats != nil {
+		span.SetAttributes(stats.attributes()...)
+		if reasons := stats.suspiciousReasons(t.SuspiciousThresholds); len(reasons) > 0 {
+			span.AddEvent(graphqlSuspicious, trace.WithAttributes(graphqlSuspiciousReasons.StringSlice(reasons)))
+		}
 	}
 	if t.IncludeVariables {
 		for name, value := range oc.Variables {
@@ -74,33 +233,98 @@ func (t Tracer) InterceptResponse(ctx context.Context, next graphql.ResponseHand
 			})
 		}
 	}
+	if t.OperationAttributes != nil {
+		span.SetAttributes(t.OperationAttributes(ctx, oc)...)
+	}
+	var tree *traceTree
+	if t.IncludeTraceTree {
+		tree = newTraceTree(spanName, oc, span.SpanContext())
+		tree.startExecute()
+		ctx = withTraceTree(ctx, tree)
+	}
+	start := time.Now()
 	res := next(ctx)
+	if timing := getServerTiming(ctx); timing != nil {
+		timing.recordExecute(time.Since(start))
+	}
+	if tree != nil && res != nil {
+		if res.Extensions == nil {
+			res.Extensions = map[string]interface{}{}
+		}
+		res.Extensions[traceTreeExtension] = tree.end(res)
+	}
 	if res != nil && len(res.Errors) > 0 {
 		span.SetStatus(codes.Error, res.Errors.Error())
 		for _, err := range res.Errors {
 			span.RecordError(err)
 		}
 	}
+	t.addTraceIDExtensions(res, span.SpanContext())
+	if t.OnOperationEnd != nil {
+		t.OnOperationEnd(ctx, span, res)
+	}
 	return res
 }
 
 func (t Tracer) InterceptField(ctx context.Context, next graphql.Resolver) (interface{}, error) {
+	t.applyDynamicSettings(ctx)
+	if isDebug(ctx) {
+		t.applyDebug()
+	}
 	fc := graphql.GetFieldContext(ctx)
-	if !t.IncludeFieldSpans || !fc.IsMethod || !fc.IsResolver {
+	if !fc.IsMethod || !fc.IsResolver {
+		return next(ctx)
+	}
+	if timing := getServerTiming(ctx); timing != nil {
+		start := time.Now()
+		defer func() {
+			timing.recordResolver(fc, time.Since(start))
+		}()
+	}
+	tree := getTraceTree(ctx)
+	var node *traceTreeNode
+	if tree != nil {
+		node = tree.startField(fc)
+		defer func() {
+			tree.endField(node, graphql.GetFieldErrors(ctx, fc))
+		}()
+	}
+	if !t.IncludeFieldSpans {
 		return next(ctx)
 	}
 	spanName := fc.Field.ObjectDefinition.Name + "." + fc.Field.Name
-	ctx, span := t.getTracer(ctx).Start(ctx, spanName, trace.WithSpanKind(trace.SpanKindServer), trace.WithAttributes(baseAttributes...))
+	ctx, span := t.getTracer(ctx).Start(ctx, spanName, trace.WithSpanKind(trace.SpanKindInternal), trace.WithAttributes(baseAttributes...))
 	defer span.End()
+	ctx = withFieldSpan(ctx, span)
+	if node != nil {
+		tree.setSpan(node, span.SpanContext())
+	}
 	span.SetAttributes(
 		graphqlFieldName.String(fc.Field.Name),
+		graphqlFieldParentType.String(fc.Field.ObjectDefinition.Name),
 		graphqlFieldPath.String(fc.Path().String()),
-		graphqlFieldType.String(fc.Field.ObjectDefinition.Name),
 	)
+	if fc.Field.Definition != nil && fc.Field.Definition.Type != nil {
+		span.SetAttributes(graphqlFieldType.String(fc.Field.Definition.Type.String()))
+	}
 	if fc.Field.Alias != fc.Field.Name {
 		span.SetAttributes(graphqlFieldAlias.String(fc.Field.Alias))
 	}
+	if t.FieldAttributes != nil {
+		span.SetAttributes(t.FieldAttributes(ctx, fc)...)
+	}
+	if t.IncludeFieldArguments {
+		for name, value := range fc.Args {
+			span.SetAttributes(attribute.KeyValue{
+				Key:   attribute.Key(graphqlFieldArgsPrefix + name),
+				Value: makeAttributeValue(value),
+			})
+		}
+	}
 	res, err := next(ctx)
+	if t.IncludeFieldResults && err == nil {
+		span.SetAttributes(makeResultAttributes(fc, res, t.getSchema())...)
+	}
 	if errList := graphql.GetFieldErrors(ctx, fc); len(errList) > 0 {
 		span.SetStatus(codes.Error, errList.Error())
 		for _, err := range errList {
@@ -111,15 +335,29 @@ func (t Tracer) InterceptField(ctx context.Context, next graphql.Resolver) (inte
 }
 
 func (t Tracer) getTracer(ctx context.Context) trace.Tracer {
-	if span := trace.SpanFromContext(ctx); span.SpanContext().IsValid() {
-		return span.TracerProvider().Tracer(extensionName, trace.WithInstrumentationVersion(extensionVersion))
-	} else {
-		tp := t.TracerProvider
-		if tp == nil {
-			tp = otel.GetTracerProvider()
-		}
-		return tp.Tracer(extensionName, trace.WithInstrumentationVersion(extensionVersion))
+	span := trace.SpanFromContext(ctx)
+	hasParent := span.SpanContext().IsValid() && !span.SpanContext().IsRemote()
+	if t.InheritTracerProvider && hasParent {
+		return t.getProviderTracer(span.TracerProvider())
+	}
+	if t.TracerProvider != nil {
+		return t.getProviderTracer(t.TracerProvider)
+	}
+	if hasParent {
+		return t.getProviderTracer(span.TracerProvider())
+	}
+	return t.getProviderTracer(otel.GetTracerProvider())
+}
+
+func (t Tracer) getProviderTracer(tp trace.TracerProvider) trace.Tracer {
+	if t.cache != nil {
+		return t.cache.getTracer(tp)
 	}
+	return newTracer(tp)
+}
+
+func newTracer(tp trace.TracerProvider) trace.Tracer {
+	return tp.Tracer(extensionName, trace.WithInstrumentationVersion(extensionVersion))
 }
 
 func makeSpanName(operationName, operationType string) string {
@@ -133,6 +371,13 @@ func makeSpanName(operationName, operationType string) string {
 	return spanName
 }
 
+func getOperationName(oc *graphql.OperationContext) string {
+	if oc.Operation != nil && oc.Operation.Name != "" {
+		return oc.Operation.Name
+	}
+	return oc.OperationName
+}
+
 func getOperationTypeAttribute(oc *graphql.OperationContext) attribute.KeyValue {
 	if oc.Operation == nil {
 		return attribute.String("", "")
@@ -151,6 +396,7 @@ func getOperationTypeAttribute(oc *graphql.OperationContext) attribute.KeyValue
 
 var _ interface {
 	graphql.HandlerExtension
+	graphql.OperationInterceptor
 	graphql.ResponseInterceptor
 	graphql.FieldInterceptor
 } = Tracer{}
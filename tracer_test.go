@@ -1,25 +1,42 @@
 package gqlgen_opentelemetry
 
 import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"regexp"
+	"strings"
 	"testing"
+	"time"
 
 	"github.com/99designs/gqlgen/client"
+	"github.com/99designs/gqlgen/graphql"
 	"github.com/99designs/gqlgen/graphql/handler"
 	"github.com/99designs/gqlgen/graphql/handler/extension"
 	"github.com/99designs/gqlgen/graphql/handler/transport"
+	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/suite"
 	"github.com/zhevron/gqlgen-opentelemetry/v2/testserver"
 	"github.com/zhevron/gqlgen-opentelemetry/v2/testserver/generated"
 	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/baggage"
 	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/propagation"
+	sdkmetric "go.opentelemetry.io/otel/sdk/metric"
+	"go.opentelemetry.io/otel/sdk/metric/metricdata"
 	sdktrace "go.opentelemetry.io/otel/sdk/trace"
 	"go.opentelemetry.io/otel/sdk/trace/tracetest"
 	semconv "go.opentelemetry.io/otel/semconv/v1.38.0"
+	"go.opentelemetry.io/otel/trace"
 )
 
 type TracerSuite struct {
 	suite.Suite
 	Exporter       *tracetest.InMemoryExporter
+	MeterProvider  *sdkmetric.MeterProvider
+	MetricReader   *sdkmetric.ManualReader
 	TracerProvider *sdktrace.TracerProvider
 }
 
@@ -30,85 +47,1339 @@ func (s *TracerSuite) SetupSuite() {
 	)
 }
 
+func (s *TracerSuite) SetupTest() {
+	s.MetricReader = sdkmetric.NewManualReader()
+	s.MeterProvider = sdkmetric.NewMeterProvider(
+		sdkmetric.WithReader(s.MetricReader),
+	)
+}
+
 func (s *TracerSuite) TearDownTest() {
 	s.Exporter.Reset()
 }
 
-func (s *TracerSuite) TestQuery_SpanCreated() {
+func (s *TracerSuite) TestQuery_SpanCreated() {
+	c := s.createTestClient(&Tracer{})
+
+	query := "query GetGreeting { greeting }"
+	var res struct{ Greeting string }
+	c.MustPost(query, &res)
+
+	spans := s.Exporter.GetSpans()
+	s.Require().Len(spans, 1)
+	s.Require().Len(spans[0].Attributes, 15)
+
+	operationName := findAttributeByName(spans[0].Attributes, semconv.GraphQLOperationNameKey)
+	s.Require().NotNil(operationName)
+	s.Require().Equal(operationName.Value.AsString(), "GetGreeting")
+
+	operationType := findAttributeByName(spans[0].Attributes, semconv.GraphQLOperationTypeKey)
+	s.Require().NotNil(operationType)
+	s.Require().Equal(*operationType, semconv.GraphQLOperationTypeQuery)
+
+	document := findAttributeByName(spans[0].Attributes, semconv.GraphQLDocumentKey)
+	s.Require().NotNil(document)
+	s.Require().Equal(document.Value.AsString(), query)
+}
+
+func (s *TracerSuite) TestQuery_WithoutFieldSpans() {
+	c := s.createTestClient(&Tracer{
+		IncludeFieldSpans: false,
+	})
+
+	var res struct{ Greeting string }
+	c.MustPost("query { greeting }", &res)
+
+	spans := s.Exporter.GetSpans()
+	s.Require().Len(spans, 1)
+}
+
+func (s *TracerSuite) TestQuery_WithFieldSpans() {
+	c := s.createTestClient(&Tracer{
+		IncludeFieldSpans: true,
+	})
+
+	var res struct{ Greeting string }
+	c.MustPost("query { greeting }", &res)
+
+	spans := s.Exporter.GetSpans()
+	s.Require().Len(spans, 2)
+	span := findSpanByName(spans, "Query.greeting")
+	s.Require().NotNil(span)
+	s.Require().Equal(trace.SpanKindInternal, span.SpanKind)
+	s.Require().Len(span.Attributes, 6)
+
+	fieldName := findAttributeByName(span.Attributes, graphqlFieldName)
+	s.Require().NotNil(fieldName)
+	s.Require().Equal(fieldName.Value.AsString(), "greeting")
+
+	parentType := findAttributeByName(span.Attributes, graphqlFieldParentType)
+	s.Require().NotNil(parentType)
+	s.Require().Equal(parentType.Value.AsString(), "Query")
+
+	fieldType := findAttributeByName(span.Attributes, graphqlFieldType)
+	s.Require().NotNil(fieldType)
+	s.Require().Equal(fieldType.Value.AsString(), "String!")
+}
+
+func (s *TracerSuite) TestQuery_WithFieldSpans_ListType() {
+	c := s.createTestClient(&Tracer{
+		IncludeFieldSpans: true,
+	})
+
+	var res struct{ Greetings []string }
+	c.MustPost("query { greetings }", &res)
+
+	spans := s.Exporter.GetSpans()
+	s.Require().Len(spans, 2)
+	span := findSpanByName(spans, "Query.greetings")
+	s.Require().NotNil(span)
+
+	fieldType := findAttributeByName(span.Attributes, graphqlFieldType)
+	s.Require().NotNil(fieldType)
+	s.Require().Equal(fieldType.Value.AsString(), "[String!]!")
+}
+
+func (s *TracerSuite) TestQuery_WithFieldSpans_Alias() {
+	c := s.createTestClient(&Tracer{
+		IncludeFieldSpans: true,
+	})
+
+	var res struct{ MyGreeting string }
+	c.MustPost("query { myGreeting: greeting }", &res)
+
+	spans := s.Exporter.GetSpans()
+	s.Require().Len(spans, 2)
+	span := findSpanByName(spans, "Query.greeting")
+	s.Require().NotNil(span)
+
+	fieldName := findAttributeByName(span.Attributes, graphqlFieldName)
+	s.Require().NotNil(fieldName)
+	s.Require().Equal(fieldName.Value.AsString(), "greeting")
+
+	fieldAlias := findAttributeByName(span.Attributes, graphqlFieldAlias)
+	s.Require().NotNil(fieldAlias)
+	s.Require().Equal(fieldAlias.Value.AsString(), "myGreeting")
+}
+
+func (s *TracerSuite) TestQuery_WithFieldResults_List() {
+	c := s.createTestClient(&Tracer{
+		IncludeFieldResults: true,
+		IncludeFieldSpans:   true,
+	})
+
+	var res struct{ Greetings []string }
+	c.MustPost("query { greetings }", &res)
+
+	spans := s.Exporter.GetSpans()
+	s.Require().Len(spans, 2)
+	span := findSpanByName(spans, "Query.greetings")
+	s.Require().NotNil(span)
+
+	resultNull := findAttributeByName(span.Attributes, graphqlFieldResultNull)
+	s.Require().NotNil(resultNull)
+	s.Require().False(resultNull.Value.AsBool())
+
+	resultCount := findAttributeByName(span.Attributes, graphqlFieldResultCount)
+	s.Require().NotNil(resultCount)
+	s.Require().Equal(resultCount.Value.AsInt64(), int64(2))
+}
+
+func (s *TracerSuite) TestQuery_WithFieldResults_Null() {
+	c := s.createTestClient(&Tracer{
+		IncludeFieldResults: true,
+		IncludeFieldSpans:   true,
+	})
+
+	var res struct{ Node *struct{ ID string } }
+	c.MustPost(`query { node(id: "2") { id } }`, &res)
+
+	spans := s.Exporter.GetSpans()
+	s.Require().Len(spans, 2)
+	span := findSpanByName(spans, "Query.node")
+	s.Require().NotNil(span)
+
+	resultNull := findAttributeByName(span.Attributes, graphqlFieldResultNull)
+	s.Require().NotNil(resultNull)
+	s.Require().True(resultNull.Value.AsBool())
+
+	resultTypename := findAttributeByName(span.Attributes, graphqlFieldResultTypename)
+	s.Require().Nil(resultTypename)
+}
+
+func (s *TracerSuite) TestQuery_WithFieldResults_Typename() {
+	c := s.createTestClient(New(WithFieldSpans(), WithFieldResults()))
+
+	var res struct{ Node *struct{ ID string } }
+	c.MustPost(`query { node(id: "1") { id } }`, &res)
+
+	spans := s.Exporter.GetSpans()
+	s.Require().Len(spans, 2)
+	span := findSpanByName(spans, "Query.node")
+	s.Require().NotNil(span)
+
+	resultNull := findAttributeByName(span.Attributes, graphqlFieldResultNull)
+	s.Require().NotNil(resultNull)
+	s.Require().False(resultNull.Value.AsBool())
+
+	resultTypename := findAttributeByName(span.Attributes, graphqlFieldResultTypename)
+	s.Require().NotNil(resultTypename)
+	s.Require().Equal(resultTypename.Value.AsString(), "Person")
+}
+
+func (s *TracerSuite) TestQuery_WithoutFieldResults() {
+	c := s.createTestClient(&Tracer{
+		IncludeFieldSpans: true,
+	})
+
+	var res struct{ Greetings []string }
+	c.MustPost("query { greetings }", &res)
+
+	spans := s.Exporter.GetSpans()
+	s.Require().Len(spans, 2)
+	span := findSpanByName(spans, "Query.greetings")
+	s.Require().NotNil(span)
+
+	resultCount := findAttributeByName(span.Attributes, graphqlFieldResultCount)
+	s.Require().Nil(resultCount)
+}
+
+func (s *TracerSuite) TestQuery_OperationStats() {
+	c := s.createTestClient(&Tracer{})
+
+	var res struct{ A, B string }
+	c.MustPost("query { a: greeting b: greeting }", &res)
+
+	spans := s.Exporter.GetSpans()
+	s.Require().Len(spans, 1)
+
+	complexityLimit := findAttributeByName(spans[0].Attributes, graphqlComplexityLimit)
+	s.Require().NotNil(complexityLimit)
+	s.Require().Equal(complexityLimit.Value.AsInt64(), int64(100))
+
+	complexityExceeded := findAttributeByName(spans[0].Attributes, graphqlComplexityExceeded)
+	s.Require().NotNil(complexityExceeded)
+	s.Require().False(complexityExceeded.Value.AsBool())
+
+	depth := findAttributeByName(spans[0].Attributes, graphqlDepth)
+	s.Require().NotNil(depth)
+	s.Require().Equal(depth.Value.AsInt64(), int64(1))
+
+	fieldCount := findAttributeByName(spans[0].Attributes, graphqlFieldCount)
+	s.Require().NotNil(fieldCount)
+	s.Require().Equal(fieldCount.Value.AsInt64(), int64(2))
+
+	aliasCount := findAttributeByName(spans[0].Attributes, graphqlAliasCount)
+	s.Require().NotNil(aliasCount)
+	s.Require().Equal(aliasCount.Value.AsInt64(), int64(2))
+}
+
+func (s *TracerSuite) TestQuery_ComplexityLimitExceeded() {
+	c := s.createTestClient(&Tracer{})
+
+	query := "query {"
+	for i := 0; i <= 100; i++ {
+		query += fmt.Sprintf(" a%d: greeting", i)
+	}
+	query += " }"
+	var res map[string]string
+	s.Require().Error(c.Post(query, &res))
+
+	spans := s.Exporter.GetSpans()
+	s.Require().Len(spans, 1)
+	s.Require().Equal(codes.Error, spans[0].Status.Code)
+
+	complexityExceeded := findAttributeByName(spans[0].Attributes, graphqlComplexityExceeded)
+	s.Require().NotNil(complexityExceeded)
+	s.Require().True(complexityExceeded.Value.AsBool())
+}
+
+func (s *TracerSuite) TestQuery_SuspiciousThresholds() {
+	c := s.createTestClient(&Tracer{
+		SuspiciousThresholds: SuspiciousThresholds{
+			AliasCount: 1,
+		},
+	})
+
+	var res struct{ A, B string }
+	c.MustPost("query { a: greeting b: greeting }", &res)
+
+	spans := s.Exporter.GetSpans()
+	s.Require().Len(spans, 1)
+	s.Require().Len(spans[0].Events, 1)
+	s.Require().Equal(graphqlSuspicious, spans[0].Events[0].Name)
+
+	reasons := findAttributeByName(spans[0].Events[0].Attributes, graphqlSuspiciousReasons)
+	s.Require().NotNil(reasons)
+	s.Require().Equal(reasons.Value.AsStringSlice(), []string{"alias_count"})
+}
+
+func (s *TracerSuite) TestQuery_SuspiciousThresholds_NotExceeded() {
+	c := s.createTestClient(&Tracer{
+		SuspiciousThresholds: SuspiciousThresholds{
+			AliasCount: 2,
+		},
+	})
+
+	var res struct{ A, B string }
+	c.MustPost("query { a: greeting b: greeting }", &res)
+
+	spans := s.Exporter.GetSpans()
+	s.Require().Len(spans, 1)
+	s.Require().Empty(spans[0].Events)
+}
+
+func (s *TracerSuite) TestQuery_OperationStatsMetrics() {
+	c := s.createTestClient(New())
+
+	var res struct{ A, B string }
+	c.MustPost("query GetGreetings { a: greeting b: greeting }", &res)
+
+	aliasCount := s.findHistogramByName(string(graphqlAliasCount))
+	s.Require().NotNil(aliasCount)
+	s.Require().Len(aliasCount.DataPoints, 1)
+	s.Require().Equal(uint64(1), aliasCount.DataPoints[0].Count)
+	s.Require().Equal(int64(2), aliasCount.DataPoints[0].Sum)
+
+	_, ok := aliasCount.DataPoints[0].Attributes.Value(semconv.GraphQLOperationNameKey)
+	s.Require().False(ok)
+
+	duplicateFieldCount := s.findHistogramByName(string(graphqlDuplicateFieldCount))
+	s.Require().NotNil(duplicateFieldCount)
+	s.Require().Equal(int64(1), duplicateFieldCount.DataPoints[0].Sum)
+}
+
+func (s *TracerSuite) TestSubscription_OperationStatsMetrics() {
+	c := s.createTestClient(New())
+
+	sub := c.Websocket("subscription { a: greetings }")
+	defer sub.Close()
+	var res struct{ A string }
+	s.Require().NoError(sub.Next(&res))
+	s.Require().NoError(sub.Next(&res))
+
+	aliasCount := s.findHistogramByName(string(graphqlAliasCount))
+	s.Require().NotNil(aliasCount)
+	s.Require().Len(aliasCount.DataPoints, 1)
+	s.Require().Equal(uint64(1), aliasCount.DataPoints[0].Count)
+	s.Require().Equal(int64(1), aliasCount.DataPoints[0].Sum)
+}
+
+func (s *TracerSuite) TestQuery_WithoutCacheMetrics() {
+	c := s.createTestClient(&Tracer{})
+
+	var res struct{ A, B string }
+	c.MustPost("query GetGreetings { a: greeting b: greeting }", &res)
+
+	s.Require().Nil(s.findHistogramByName(string(graphqlAliasCount)))
+}
+
+func (s *TracerSuite) TestQuery_MetricOperationName() {
+	c := s.createTestClient(New(WithMetricOperationName(AllowList("GetGreetings"))))
+
+	var res struct{ A, B string }
+	c.MustPost("query GetGreetings { a: greeting b: greeting }", &res)
+	c.MustPost("query GetUnknown { a: greeting b: greeting }", &res)
+
+	aliasCount := s.findHistogramByName(string(graphqlAliasCount))
+	s.Require().NotNil(aliasCount)
+	var names []string
+	for _, dp := range aliasCount.DataPoints {
+		operationName, ok := dp.Attributes.Value(semconv.GraphQLOperationNameKey)
+		s.Require().True(ok)
+		names = append(names, operationName.AsString())
+	}
+	s.Require().ElementsMatch([]string{"GetGreetings", "other"}, names)
+}
+
+func (s *TracerSuite) TestQuery_Deprecated() {
+	c := s.createTestClient(New())
+
+	var res struct{ Hello, LocalizedGreeting string }
+	c.MustPost("query GetHello { hello localizedGreeting(language: OLD_NORSE) }", &res)
+
+	spans := s.Exporter.GetSpans()
+	s.Require().Len(spans, 1)
+
+	deprecated := findAttributeByName(spans[0].Attributes, graphqlDeprecated)
+	s.Require().NotNil(deprecated)
+	s.Require().Equal(deprecated.Value.AsStringSlice(), []string{"Language.OLD_NORSE", "Query.hello"})
+
+	usage := s.findSumByName(graphqlDeprecatedUsage)
+	s.Require().NotNil(usage)
+	s.Require().Len(usage.DataPoints, 2)
+	for _, dp := range usage.DataPoints {
+		s.Require().Equal(int64(1), dp.Value)
+		coordinate, ok := dp.Attributes.Value(graphqlSchemaCoordinate)
+		s.Require().True(ok)
+		s.Require().Contains([]string{"Language.OLD_NORSE", "Query.hello"}, coordinate.AsString())
+	}
+}
+
+func (s *TracerSuite) TestSubscription_Deprecated() {
+	c := s.createTestClient(New())
+
+	sub := c.Websocket("subscription { hello }")
+	defer sub.Close()
+	var res struct{ Hello string }
+	s.Require().NoError(sub.Next(&res))
+	s.Require().NoError(sub.Next(&res))
+
+	usage := s.findSumByName(graphqlDeprecatedUsage)
+	s.Require().NotNil(usage)
+	s.Require().Len(usage.DataPoints, 1)
+	s.Require().Equal(int64(1), usage.DataPoints[0].Value)
+}
+
+func (s *TracerSuite) TestQuery_DeprecatedInputFields() {
+	c := s.createTestClient(New())
+
+	var res struct{ CustomGreeting string }
+	c.MustPost(
+		"query GetGreeting($input: GreetingInput!) { customGreeting(input: $input) }",
+		&res,
+		client.Var("input", map[string]interface{}{"name": "gqlgen", "title": "Dr.", "language": "OLD_NORSE"}),
+	)
+	s.Require().Equal("Hello Dr. gqlgen", res.CustomGreeting)
+
+	spans := s.Exporter.GetSpans()
+	s.Require().Len(spans, 1)
+
+	deprecated := findAttributeByName(spans[0].Attributes, graphqlDeprecated)
+	s.Require().NotNil(deprecated)
+	s.Require().Equal(deprecated.Value.AsStringSlice(), []string{"GreetingInput.title", "Language.OLD_NORSE"})
+}
+
+func (s *TracerSuite) TestQuery_NotDeprecated() {
+	c := s.createTestClient(New())
+
+	var res struct{ Greeting string }
+	c.MustPost("query { greeting }", &res)
+
+	spans := s.Exporter.GetSpans()
+	s.Require().Len(spans, 1)
+
+	deprecated := findAttributeByName(spans[0].Attributes, graphqlDeprecated)
+	s.Require().Nil(deprecated)
+	s.Require().Nil(s.findSumByName(graphqlDeprecatedUsage))
+}
+
+func (s *TracerSuite) TestQuery_UsageCollector() {
+	collector := NewUsageCollector()
+	c := s.createTestClient(&Tracer{
+		UsageCollector: collector,
+	})
+
+	var res struct{ Greeting string }
+	c.MustPost("query { greeting }", &res)
+	c.MustPost("query { greeting }", &res)
+
+	snapshot := collector.Snapshot()
+	s.Require().Equal(int64(2), snapshot["Query.greeting"].Count)
+	s.Require().Contains(snapshot, "Query.greetings")
+	s.Require().Equal(int64(0), snapshot["Query.greetings"].Count)
+}
+
+func (s *TracerSuite) TestSubscription_UsageCollector() {
+	collector := NewUsageCollector()
+	c := s.createTestClient(&Tracer{
+		UsageCollector: collector,
+	})
+
+	sub := c.Websocket("subscription { greetings }")
+	defer sub.Close()
+	var res struct{ Greetings string }
+	s.Require().NoError(sub.Next(&res))
+	s.Require().NoError(sub.Next(&res))
+
+	snapshot := collector.Snapshot()
+	s.Require().Equal(int64(1), snapshot["Subscription.greetings"].Count)
+}
+
+func (s *TracerSuite) TestQuery_ClientInfo() {
+	c := s.createTestClient(New())
+
+	var res struct{ Hello string }
+	c.MustPost(
+		"query GetHello { hello }",
+		&res,
+		client.AddHeader("apollographql-client-name", "web"),
+		client.AddHeader("apollographql-client-version", "1.2.3"),
+	)
+
+	spans := s.Exporter.GetSpans()
+	s.Require().Len(spans, 1)
+
+	clientName := findAttributeByName(spans[0].Attributes, graphqlClientName)
+	s.Require().NotNil(clientName)
+	s.Require().Equal(clientName.Value.AsString(), "web")
+
+	clientVersion := findAttributeByName(spans[0].Attributes, graphqlClientVersion)
+	s.Require().NotNil(clientVersion)
+	s.Require().Equal(clientVersion.Value.AsString(), "1.2.3")
+
+	usage := s.findSumByName(graphqlDeprecatedUsage)
+	s.Require().NotNil(usage)
+	s.Require().Len(usage.DataPoints, 1)
+	_, ok := usage.DataPoints[0].Attributes.Value(graphqlClientName)
+	s.Require().False(ok)
+	_, ok = usage.DataPoints[0].Attributes.Value(graphqlClientVersion)
+	s.Require().False(ok)
+}
+
+func (s *TracerSuite) TestQuery_MetricClientName() {
+	c := s.createTestClient(New(WithMetricClientName(AllowList("web"))))
+
+	var res struct{ Hello string }
+	for _, name := range []string{"web", "curl"} {
+		c.MustPost(
+			"query GetHello { hello }",
+			&res,
+			client.AddHeader("apollographql-client-name", name),
+			client.AddHeader("apollographql-client-version", "1.2.3"),
+		)
+	}
+
+	usage := s.findSumByName(graphqlDeprecatedUsage)
+	s.Require().NotNil(usage)
+	var names []string
+	for _, dp := range usage.DataPoints {
+		metricClientName, ok := dp.Attributes.Value(graphqlClientName)
+		s.Require().True(ok)
+		names = append(names, metricClientName.AsString())
+		_, ok = dp.Attributes.Value(graphqlClientVersion)
+		s.Require().False(ok)
+	}
+	s.Require().ElementsMatch([]string{"web", "other"}, names)
+}
+
+func (s *TracerSuite) TestQuery_OperationAttributes() {
+	c := s.createTestClient(&Tracer{
+		OperationAttributes: func(ctx context.Context, oc *graphql.OperationContext) []attribute.KeyValue {
+			return []attribute.KeyValue{attribute.String("tenant.id", "tenant-"+oc.OperationName)}
+		},
+	})
+
+	var res struct{ Greeting string }
+	c.MustPost("query GetGreeting { greeting }", &res, client.Operation("GetGreeting"))
+
+	spans := s.Exporter.GetSpans()
+	s.Require().Len(spans, 1)
+
+	tenantID := findAttributeByName(spans[0].Attributes, "tenant.id")
+	s.Require().NotNil(tenantID)
+	s.Require().Equal(tenantID.Value.AsString(), "tenant-GetGreeting")
+}
+
+func (s *TracerSuite) TestQuery_FieldAttributes() {
+	c := s.createTestClient(&Tracer{
+		IncludeFieldSpans: true,
+		FieldAttributes: func(ctx context.Context, fc *graphql.FieldContext) []attribute.KeyValue {
+			return []attribute.KeyValue{attribute.String("field.object", fc.Object)}
+		},
+	})
+
+	var res struct{ Greeting string }
+	c.MustPost("query { greeting }", &res)
+
+	spans := s.Exporter.GetSpans()
+	s.Require().Len(spans, 2)
+	span := findSpanByName(spans, "Query.greeting")
+	s.Require().NotNil(span)
+
+	fieldObject := findAttributeByName(span.Attributes, "field.object")
+	s.Require().NotNil(fieldObject)
+	s.Require().Equal(fieldObject.Value.AsString(), "Query")
+}
+
+func (s *TracerSuite) TestQuery_OnOperationEnd() {
+	c := s.createTestClient(&Tracer{
+		OnOperationEnd: func(ctx context.Context, span trace.Span, res *graphql.Response) {
+			span.SetAttributes(attribute.Int("response.size", len(res.Data)))
+		},
+	})
+
+	var res struct{ Greeting string }
+	c.MustPost("query { greeting }", &res)
+
+	spans := s.Exporter.GetSpans()
+	s.Require().Len(spans, 1)
+
+	responseSize := findAttributeByName(spans[0].Attributes, "response.size")
+	s.Require().NotNil(responseSize)
+	s.Require().Equal(responseSize.Value.AsInt64(), int64(len(`{"greeting":"Hello world"}`)))
+}
+
+func (s *TracerSuite) TestQuery_OperationAndFieldSpan() {
+	var operationSpan, fieldSpan trace.SpanContext
+	c := s.createTestClient(&Tracer{
+		IncludeFieldSpans: true,
+		FieldAttributes: func(ctx context.Context, fc *graphql.FieldContext) []attribute.KeyValue {
+			operationSpan = OperationSpan(ctx).SpanContext()
+			fieldSpan = FieldSpan(ctx).SpanContext()
+			return nil
+		},
+	})
+
+	var res struct{ Greeting string }
+	c.MustPost("query { greeting }", &res)
+
+	spans := s.Exporter.GetSpans()
+	s.Require().Len(spans, 2)
+	s.Require().Equal(findSpanByName(spans, "query").SpanContext, operationSpan)
+	s.Require().Equal(findSpanByName(spans, "Query.greeting").SpanContext, fieldSpan)
+}
+
+func (s *TracerSuite) TestQuery_HTTPSpanNested() {
+	c := s.createTestClientWithServerSpan(&Tracer{})
+
+	var res struct{ Greeting string }
+	c.MustPost("query GetGreeting { greeting }", &res)
+
+	spans := s.Exporter.GetSpans()
+	s.Require().Len(spans, 2)
+	s.Require().NotNil(findSpanByName(spans, "POST"))
+
+	span := findSpanByName(spans, "query GetGreeting")
+	s.Require().NotNil(span)
+	s.Require().Equal(trace.SpanKindServer, span.SpanKind)
+}
+
+func (s *TracerSuite) TestQuery_HTTPSpanReuse() {
+	c := s.createTestClientWithServerSpan(&Tracer{
+		HTTPRoute:    "/graphql",
+		HTTPSpanMode: HTTPSpanReuse,
+	})
+
+	var res struct{ Greeting string }
+	c.MustPost("query GetGreeting { greeting }", &res)
+
+	spans := s.Exporter.GetSpans()
+	s.Require().Len(spans, 1)
+	s.Require().Equal("query GetGreeting", spans[0].Name)
+	s.Require().Equal(trace.SpanKindServer, spans[0].SpanKind)
+
+	route := findAttributeByName(spans[0].Attributes, semconv.HTTPRouteKey)
+	s.Require().NotNil(route)
+	s.Require().Equal(route.Value.AsString(), "/graphql")
+
+	operationName := findAttributeByName(spans[0].Attributes, semconv.GraphQLOperationNameKey)
+	s.Require().NotNil(operationName)
+	s.Require().Equal(operationName.Value.AsString(), "GetGreeting")
+
+	complexity := findAttributeByName(spans[0].Attributes, graphqlComplexity)
+	s.Require().NotNil(complexity)
+}
+
+func (s *TracerSuite) TestQuery_HTTPSpanChild() {
+	c := s.createTestClientWithServerSpan(&Tracer{
+		HTTPSpanMode: HTTPSpanChild,
+	})
+
+	var res struct{ Greeting string }
+	c.MustPost("query GetGreeting { greeting }", &res)
+
+	spans := s.Exporter.GetSpans()
+	s.Require().Len(spans, 2)
+
+	var parent, child *tracetest.SpanStub
+	for i := range spans {
+		if spans[i].SpanKind == trace.SpanKindServer {
+			parent = &spans[i]
+		} else {
+			child = &spans[i]
+		}
+	}
+	s.Require().NotNil(parent)
+	s.Require().NotNil(child)
+	s.Require().Equal("query GetGreeting", parent.Name)
+	s.Require().Nil(findAttributeByName(parent.Attributes, semconv.HTTPRouteKey))
+	s.Require().NotNil(findAttributeByName(parent.Attributes, semconv.GraphQLOperationNameKey))
+	s.Require().Nil(findAttributeByName(parent.Attributes, graphqlComplexity))
+
+	s.Require().Equal("query GetGreeting", child.Name)
+	s.Require().Equal(trace.SpanKindInternal, child.SpanKind)
+	s.Require().Equal(parent.SpanContext.SpanID(), child.Parent.SpanID())
+	s.Require().NotNil(findAttributeByName(child.Attributes, graphqlComplexity))
+}
+
+func (s *TracerSuite) TestQuery_HTTPSpanReuse_WithoutServerSpan() {
+	c := s.createTestClient(&Tracer{
+		HTTPSpanMode: HTTPSpanReuse,
+	})
+
+	var res struct{ Greeting string }
+	c.MustPost("query GetGreeting { greeting }", &res)
+
+	spans := s.Exporter.GetSpans()
+	s.Require().Len(spans, 1)
+	s.Require().Equal("query GetGreeting", spans[0].Name)
+	s.Require().Equal(trace.SpanKindServer, spans[0].SpanKind)
+}
+
+func (s *TracerSuite) TestSubscription_HTTPSpanReuse_Websocket() {
+	for _, mode := range []HTTPSpanMode{HTTPSpanReuse, HTTPSpanChild} {
+		s.Exporter.Reset()
+		c := s.createTestClientWithServerSpan(&Tracer{
+			HTTPSpanMode: mode,
+		})
+
+		sub := c.Websocket("subscription Greetings { greetings }")
+		var res struct{ Greetings string }
+		s.Require().NoError(sub.Next(&res))
+		s.Require().NoError(sub.Close())
+
+		s.Require().Eventually(func() bool {
+			return findSpanByName(s.Exporter.GetSpans(), "POST") != nil
+		}, time.Second, 10*time.Millisecond)
+		spans := s.Exporter.GetSpans()
+		s.Require().Nil(findAttributeByName(findSpanByName(spans, "POST").Attributes, semconv.GraphQLOperationNameKey))
+
+		span := findSpanByName(spans, "subscription Greetings")
+		s.Require().NotNil(span)
+		s.Require().Equal(trace.SpanKindServer, span.SpanKind)
+	}
+}
+
+func (s *TracerSuite) TestSubscription_HTTPSpanReuse_NamedWebsocket() {
+	h := handler.New(generated.NewExecutableSchema(generated.Config{
+		Resolvers: &testserver.Resolver{},
+	}))
+	h.AddTransport(NamedTransport("ws", transport.Websocket{}))
+	h.Use(&Tracer{
+		HTTPSpanMode:   HTTPSpanReuse,
+		TracerProvider: s.TracerProvider,
+	})
+	c := client.New(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		ctx, span := s.TracerProvider.Tracer("test").Start(r.Context(), "GET", trace.WithSpanKind(trace.SpanKindServer))
+		defer span.End()
+		h.ServeHTTP(w, r.WithContext(ctx))
+	}))
+
+	sub := c.Websocket("subscription Greetings { greetings }")
+	var res struct{ Greetings string }
+	s.Require().NoError(sub.Next(&res))
+	s.Require().NoError(sub.Close())
+
+	s.Require().Eventually(func() bool {
+		return findSpanByName(s.Exporter.GetSpans(), "GET") != nil
+	}, time.Second, 10*time.Millisecond)
+	spans := s.Exporter.GetSpans()
+	s.Require().Nil(findAttributeByName(findSpanByName(spans, "GET").Attributes, semconv.GraphQLOperationNameKey))
+
+	span := findSpanByName(spans, "subscription Greetings")
+	s.Require().NotNil(span)
+	s.Require().Equal(trace.SpanKindServer, span.SpanKind)
+	s.Require().Equal("ws", findAttributeByName(span.Attributes, graphqlTransport).Value.AsString())
+}
+
+func (s *TracerSuite) TestQuery_TracerProviderPrecedence() {
+	parentExporter := tracetest.NewInMemoryExporter()
+	parentProvider := sdktrace.NewTracerProvider(sdktrace.WithSyncer(parentExporter))
+	c := s.createTestClientWithParentSpan(&Tracer{}, parentProvider)
+
+	var res struct{ Greeting string }
+	c.MustPost("query GetGreeting { greeting }", &res)
+
+	s.Require().Len(parentExporter.GetSpans(), 1)
+	spans := s.Exporter.GetSpans()
+	s.Require().Len(spans, 1)
+	s.Require().Equal("query GetGreeting", spans[0].Name)
+}
+
+func (s *TracerSuite) TestQuery_InheritTracerProvider() {
+	parentExporter := tracetest.NewInMemoryExporter()
+	parentProvider := sdktrace.NewTracerProvider(sdktrace.WithSyncer(parentExporter))
+	c := s.createTestClientWithParentSpan(&Tracer{
+		InheritTracerProvider: true,
+	}, parentProvider)
+
+	var res struct{ Greeting string }
+	c.MustPost("query GetGreeting { greeting }", &res)
+
+	s.Require().Empty(s.Exporter.GetSpans())
+	spans := parentExporter.GetSpans()
+	s.Require().Len(spans, 2)
+	s.Require().NotNil(findSpanByName(spans, "query GetGreeting"))
+}
+
+func (s *TracerSuite) TestQuery_InheritTracerProvider_Alternating() {
+	parentExporter := tracetest.NewInMemoryExporter()
+	parentProvider := sdktrace.NewTracerProvider(sdktrace.WithSyncer(parentExporter))
+	tracer := New(WithInheritTracerProvider())
+	withParent := s.createTestClientWithParentSpan(tracer, parentProvider)
+	withoutParent := client.New(s.createTestHandler(tracer))
+
+	var res struct{ Greeting string }
+	for i := 0; i < 2; i++ {
+		withParent.MustPost("query GetGreeting { greeting }", &res)
+		withoutParent.MustPost("query GetGreeting { greeting }", &res)
+	}
+
+	s.Require().Len(parentExporter.GetSpans(), 4)
+	s.Require().Len(s.Exporter.GetSpans(), 2)
+
+	var providers []interface{}
+	tracer.cache.tracers.Range(func(key, value interface{}) bool {
+		providers = append(providers, key)
+		return true
+	})
+	s.Require().ElementsMatch([]interface{}{parentProvider, s.TracerProvider}, providers)
+	s.Require().Same(tracer.cache.getTracer(parentProvider), tracer.cache.getTracer(parentProvider))
+}
+
+func (s *TracerSuite) TestQuery_New() {
+	c := s.createTestClient(New(
+		WithFieldSpans(),
+		WithTracerProvider(s.TracerProvider),
+	))
+
+	var res struct{ Greeting string }
+	c.MustPost("query { greeting }", &res)
+
+	spans := s.Exporter.GetSpans()
+	s.Require().Len(spans, 2)
+	s.Require().NotNil(findSpanByName(spans, "Query.greeting"))
+	s.Require().NotNil(s.findHistogramByName(string(graphqlAliasCount)))
+}
+
+func (s *TracerSuite) TestQuery_WithoutDocument() {
+	c := s.createTestClient(&Tracer{
+		OmitDocument: true,
+	})
+
+	var res struct{ Greeting string }
+	c.MustPost("query { greeting }", &res)
+
+	spans := s.Exporter.GetSpans()
+	s.Require().Len(spans, 1)
+	s.Require().Nil(findAttributeByName(spans[0].Attributes, semconv.GraphQLDocumentKey))
+}
+
+func (s *TracerSuite) TestQuery_DynamicConfig() {
+	dynamic := NewDynamicConfig()
+	c := s.createTestClient(&Tracer{
+		DynamicConfig: dynamic,
+	})
+
+	var res struct{ Greeting string }
+	c.MustPost("query { greeting }", &res)
+	s.Require().Len(s.Exporter.GetSpans(), 1)
+	s.Exporter.Reset()
+
+	s.Require().NoError(dynamic.Set(Settings{FieldSpans: boolPtr(true)}, time.Minute))
+	c.MustPost("query { greeting }", &res)
+	s.Require().Len(s.Exporter.GetSpans(), 2)
+	s.Exporter.Reset()
+
+	dynamic.Reset()
+	c.MustPost("query { greeting }", &res)
+	s.Require().Len(s.Exporter.GetSpans(), 1)
+}
+
+func (s *TracerSuite) TestQuery_DebugHeader() {
+	c := s.createTestClient(&Tracer{
+		OmitDocument: true,
+		DebugAuthorizer: func(ctx context.Context) bool {
+			return graphql.GetOperationContext(ctx).Headers.Get("Authorization") == "Bearer admin"
+		},
+	})
+
+	var res struct{ Greet string }
+	c.MustPost(
+		"mutation Greet($name: String!) { greet(name: $name) }",
+		&res,
+		client.Var("name", "gqlgen"),
+		client.AddHeader("X-GraphQL-Debug", "true"),
+		client.AddHeader("Authorization", "Bearer admin"),
+	)
+
+	spans := s.Exporter.GetSpans()
+	s.Require().Len(spans, 2)
+	span := findSpanByName(spans, "mutation Greet")
+	s.Require().NotNil(span)
+	s.Require().NotNil(findAttributeByName(span.Attributes, graphqlDebug))
+	s.Require().NotNil(findAttributeByName(span.Attributes, graphqlVariablesPrefix+"name"))
+	s.Require().NotNil(findAttributeByName(span.Attributes, semconv.GraphQLDocumentKey))
+	s.Require().NotNil(findSpanByName(spans, "Mutation.greet"))
+}
+
+func (s *TracerSuite) TestQuery_DebugHeader_Unauthorized() {
+	c := s.createTestClient(&Tracer{
+		DebugAuthorizer: func(ctx context.Context) bool {
+			return graphql.GetOperationContext(ctx).Headers.Get("Authorization") == "Bearer admin"
+		},
+	})
+
+	var res struct{ Greeting string }
+	c.MustPost("query { greeting }", &res, client.AddHeader("X-GraphQL-Debug", "true"))
+
+	spans := s.Exporter.GetSpans()
+	s.Require().Len(spans, 1)
+	s.Require().Nil(findAttributeByName(spans[0].Attributes, graphqlDebug))
+}
+
+func (s *TracerSuite) TestQuery_DebugHeader_ForceSampled() {
+	h := s.createTestHandler(&Tracer{
+		DebugAuthorizer: func(ctx context.Context) bool {
+			return true
+		},
+	})
+	c := client.New(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		sc := trace.NewSpanContext(trace.SpanContextConfig{
+			TraceID: trace.TraceID{1},
+			SpanID:  trace.SpanID{1},
+			Remote:  true,
+		})
+		h.ServeHTTP(w, r.WithContext(trace.ContextWithRemoteSpanContext(r.Context(), sc)))
+	}))
+
+	var res struct{ Greeting string }
+	c.MustPost("query { greeting }", &res)
+	s.Require().Empty(s.Exporter.GetSpans())
+
+	c.MustPost("query { greeting }", &res, client.AddHeader("X-GraphQL-Debug", "true"))
+	spans := s.Exporter.GetSpans()
+	s.Require().Len(spans, 2)
+	s.Require().Equal(trace.TraceID{1}, spans[0].SpanContext.TraceID())
+}
+
+func (s *TracerSuite) TestQuery_DebugHeader_DebugSampler() {
+	exporter := tracetest.NewInMemoryExporter()
+	h := handler.New(generated.NewExecutableSchema(generated.Config{
+		Resolvers: &testserver.Resolver{},
+	}))
+	h.AddTransport(transport.POST{})
+	h.Use(&Tracer{
+		DebugAuthorizer: func(ctx context.Context) bool {
+			return true
+		},
+		MeterProvider: s.MeterProvider,
+		TracerProvider: sdktrace.NewTracerProvider(
+			sdktrace.WithSampler(DebugSampler(sdktrace.NeverSample())),
+			sdktrace.WithSyncer(exporter),
+		),
+	})
+	c := client.New(h)
+
+	var res struct{ Greeting string }
+	c.MustPost("query { greeting }", &res)
+	s.Require().Empty(exporter.GetSpans())
+
+	c.MustPost("query { greeting }", &res, client.AddHeader("X-GraphQL-Debug", "true"))
+	spans := exporter.GetSpans()
+	s.Require().Len(spans, 2)
+	span := findSpanByName(spans, "query")
+	s.Require().NotNil(span)
+	s.Require().False(span.Parent.IsValid())
+}
+
+func (s *TracerSuite) TestQuery_TraceIDExtension() {
+	c := s.createTestClient(&Tracer{
+		IncludeTraceIDExtension: true,
+		IncludeTraceIDInErrors:  true,
+	})
+
+	res, err := c.RawPost(`mutation { greet(name: "") }`)
+	s.Require().NoError(err)
+
+	spans := s.Exporter.GetSpans()
+	s.Require().Len(spans, 1)
+	s.Require().Equal(spans[0].SpanContext.TraceID().String(), res.Extensions["traceId"])
+	s.Require().Equal(spans[0].SpanContext.SpanID().String(), res.Extensions["spanId"])
+
+	var errs []struct{ Extensions map[string]string }
+	s.Require().NoError(json.Unmarshal(res.Errors, &errs))
+	s.Require().Len(errs, 1)
+	s.Require().Equal(spans[0].SpanContext.TraceID().String(), errs[0].Extensions["traceId"])
+}
+
+func (s *TracerSuite) TestQuery_WithoutTraceIDExtension() {
 	c := s.createTestClient(&Tracer{})
 
-	query := "query GetGreeting { greeting }"
+	res, err := c.RawPost("query { greeting }")
+	s.Require().NoError(err)
+	s.Require().NotContains(res.Extensions, "traceId")
+}
+
+func (s *TracerSuite) TestQuery_ServerTiming() {
+	h := ServerTiming(s.createTestHandler(&Tracer{}))
+
+	rec := httptest.NewRecorder()
+	req := httptest.NewRequest(http.MethodPost, "/", strings.NewReader(`{"query": "query { greeting greetings }"}`))
+	req.Header.Set("Content-Type", "application/json")
+	h.ServeHTTP(rec, req)
+
+	s.Require().Equal(http.StatusOK, rec.Code)
+	header := rec.Header().Get("Server-Timing")
+	s.Require().Regexp(regexp.MustCompile(`^gql-parse;dur=[0-9.]+, gql-validate;dur=[0-9.]+, gql-execute;dur=[0-9.]+`), header)
+	s.Require().Contains(header, `desc="greeting"`)
+	s.Require().Contains(header, `desc="greetings"`)
+}
+
+func (s *TracerSuite) TestSubscription_ServerTiming() {
+	c := client.New(ServerTiming(s.createTestHandler(&Tracer{})))
+
+	sub := c.Websocket("subscription Greetings { greetings }")
+	var res struct{ Greetings string }
+	s.Require().NoError(sub.Next(&res))
+	s.Require().NotEmpty(res.Greetings)
+	s.Require().NoError(sub.Close())
+}
+
+func (s *TracerSuite) TestQuery_TraceTree() {
+	c := s.createTestClient(&Tracer{
+		IncludeTraceTree: true,
+	})
+
+	res, err := c.RawPost(`query GetNode { node(id: "1") { id } greeting }`)
+	s.Require().NoError(err)
+
+	data, err := json.Marshal(res.Extensions["otelTrace"])
+	s.Require().NoError(err)
+	var root traceTreeNode
+	s.Require().NoError(json.Unmarshal(data, &root))
+
+	spans := s.Exporter.GetSpans()
+	s.Require().Len(spans, 1)
+	s.Require().Equal("query GetNode", root.Name)
+	s.Require().Equal(spans[0].SpanContext.SpanID().String(), root.SpanID)
+
+	execute := root.Children[len(root.Children)-1]
+	s.Require().Equal("execute", execute.Name)
+	s.Require().Len(execute.Children, 2)
+	var names []string
+	for _, child := range execute.Children {
+		names = append(names, child.Name)
+	}
+	s.Require().ElementsMatch([]string{"Query.node", "Query.greeting"}, names)
+}
+
+func (s *TracerSuite) TestQuery_WithoutTraceTree() {
+	c := s.createTestClient(&Tracer{})
+
+	res, err := c.RawPost("query { greeting }")
+	s.Require().NoError(err)
+	s.Require().NotContains(res.Extensions, "otelTrace")
+}
+
+func (s *TracerSuite) TestQuery_ExtensionPropagation() {
+	var member string
+	c := s.createTestClient(&Tracer{
+		ExtensionPropagation: true,
+		Propagator:           propagation.NewCompositeTextMapPropagator(propagation.TraceContext{}, propagation.Baggage{}),
+		OperationAttributes: func(ctx context.Context, oc *graphql.OperationContext) []attribute.KeyValue {
+			member = baggage.FromContext(ctx).Member("tenant").Value()
+			return nil
+		},
+	})
+
 	var res struct{ Greeting string }
-	c.MustPost(query, &res)
+	c.MustPost("query { greeting }", &res, client.Extensions(map[string]interface{}{
+		"traceparent": "00-0af7651916cd43dd8448eb211c80319c-b7ad6b7169203331-01",
+		"baggage":     "tenant=acme",
+	}))
 
 	spans := s.Exporter.GetSpans()
 	s.Require().Len(spans, 1)
-	s.Require().Len(spans[0].Attributes, 6)
+	s.Require().Equal("0af7651916cd43dd8448eb211c80319c", spans[0].SpanContext.TraceID().String())
+	s.Require().Equal("b7ad6b7169203331", spans[0].Parent.SpanID().String())
+	s.Require().True(spans[0].Parent.IsRemote())
+	s.Require().Empty(spans[0].Links)
+	s.Require().Equal("acme", member)
+}
 
-	operationName := findAttributeByName(spans[0].Attributes, semconv.GraphQLOperationNameKey)
-	s.Require().NotNil(operationName)
-	s.Require().Equal(operationName.Value.AsString(), "GetGreeting")
+func (s *TracerSuite) TestQuery_ExtensionPropagationWithParentSpan() {
+	c := s.createTestClientWithServerSpan(&Tracer{
+		ExtensionPropagation: true,
+		Propagator:           propagation.TraceContext{},
+	})
 
-	operationType := findAttributeByName(spans[0].Attributes, semconv.GraphQLOperationTypeKey)
-	s.Require().NotNil(operationType)
-	s.Require().Equal(*operationType, semconv.GraphQLOperationTypeQuery)
+	var res struct{ Greeting string }
+	c.MustPost("query { greeting }", &res, client.Extensions(map[string]interface{}{
+		"traceparent": "00-0af7651916cd43dd8448eb211c80319c-b7ad6b7169203331-01",
+	}))
 
-	document := findAttributeByName(spans[0].Attributes, semconv.GraphQLDocumentKey)
-	s.Require().NotNil(document)
-	s.Require().Equal(document.Value.AsString(), query)
+	spans := s.Exporter.GetSpans()
+	s.Require().Len(spans, 2)
+	operationSpan := findSpanByName(spans, "query")
+	s.Require().NotNil(operationSpan)
+	httpSpan := findSpanByName(spans, "POST")
+	s.Require().NotNil(httpSpan)
+	s.Require().Equal("b7ad6b7169203331", operationSpan.Parent.SpanID().String())
+	s.Require().Len(operationSpan.Links, 1)
+	s.Require().Equal(httpSpan.SpanContext.SpanID(), operationSpan.Links[0].SpanContext.SpanID())
 }
 
-func (s *TracerSuite) TestQuery_WithoutFieldSpans() {
+func (s *TracerSuite) TestQuery_WithoutExtensionPropagation() {
 	c := s.createTestClient(&Tracer{
-		IncludeFieldSpans: false,
+		Propagator: propagation.TraceContext{},
 	})
 
 	var res struct{ Greeting string }
-	c.MustPost("query { greeting }", &res)
+	c.MustPost("query { greeting }", &res, client.Extensions(map[string]interface{}{
+		"traceparent": "00-0af7651916cd43dd8448eb211c80319c-b7ad6b7169203331-01",
+	}))
 
 	spans := s.Exporter.GetSpans()
 	s.Require().Len(spans, 1)
+	s.Require().False(spans[0].Parent.IsValid())
 }
 
-func (s *TracerSuite) TestQuery_WithFieldSpans() {
+func (s *TracerSuite) TestSubscription_InitPayloadPropagation() {
 	c := s.createTestClient(&Tracer{
-		IncludeFieldSpans: true,
+		ExtensionPropagation: true,
+		Propagator:           propagation.TraceContext{},
+	})
+
+	sub := c.WebsocketWithPayload("subscription { greetings }", map[string]interface{}{
+		"traceparent": "00-0af7651916cd43dd8448eb211c80319c-b7ad6b7169203331-01",
+	})
+	defer sub.Close()
+	var res struct{ Greetings string }
+	s.Require().NoError(sub.Next(&res))
+	s.Require().Equal("Hello world", res.Greetings)
+
+	spans := s.Exporter.GetSpans()
+	s.Require().NotEmpty(spans)
+	s.Require().Equal("subscription", spans[0].Name)
+	s.Require().False(spans[0].Parent.IsValid())
+	s.Require().Len(spans[0].Links, 1)
+	s.Require().Equal("b7ad6b7169203331", spans[0].Links[0].SpanContext.SpanID().String())
+}
+
+func (s *TracerSuite) TestSubscription_MessageExtensionPropagation() {
+	c := s.createTestClient(&Tracer{
+		ExtensionPropagation: true,
+		Propagator:           propagation.TraceContext{},
+	})
+
+	sub := c.WebsocketWithPayload("subscription { greetings }", map[string]interface{}{
+		"traceparent": "00-0af7651916cd43dd8448eb211c80319c-b7ad6b7169203331-01",
+	}, client.Extensions(map[string]interface{}{
+		"traceparent": "00-4bf92f3577b34da6a3ce929d0e0e4736-00f067aa0ba902b7-01",
+	}))
+	defer sub.Close()
+	var res struct{ Greetings string }
+	s.Require().NoError(sub.Next(&res))
+
+	spans := s.Exporter.GetSpans()
+	s.Require().NotEmpty(spans)
+	s.Require().Equal("4bf92f3577b34da6a3ce929d0e0e4736", spans[0].SpanContext.TraceID().String())
+	s.Require().Equal("00f067aa0ba902b7", spans[0].Parent.SpanID().String())
+	s.Require().Len(spans[0].Links, 1)
+	s.Require().Equal("b7ad6b7169203331", spans[0].Links[0].SpanContext.SpanID().String())
+}
+
+func (s *TracerSuite) TestWebsocket_ConnectionSpan() {
+	c := s.createTestClientWithWebsocket(New(), transport.Websocket{})
+
+	sub := c.Websocket("subscription Greetings { greetings }")
+	var res struct{ Greetings string }
+	s.Require().NoError(sub.Next(&res))
+	s.Require().NoError(sub.Next(&res))
+	s.Require().NoError(sub.Close())
+
+	s.Require().Eventually(func() bool {
+		return findSpanByName(s.Exporter.GetSpans(), websocketSpanName) != nil
+	}, time.Second, 10*time.Millisecond)
+	spans := s.Exporter.GetSpans()
+	connectionSpan := findSpanByName(spans, websocketSpanName)
+	s.Require().Equal(trace.SpanKindServer, connectionSpan.SpanKind)
+	s.Require().Equal(int64(1000), findAttributeByName(connectionSpan.Attributes, graphqlWebsocketCloseCode).Value.AsInt64())
+	s.Require().Equal("normal closure", findAttributeByName(connectionSpan.Attributes, graphqlWebsocketCloseReason).Value.AsString())
+	s.Require().Equal(int64(1), findAttributeByName(connectionSpan.Attributes, graphqlWebsocketOperationCount).Value.AsInt64())
+	s.Require().GreaterOrEqual(findAttributeByName(connectionSpan.Attributes, graphqlWebsocketOutgoingMessageCount).Value.AsInt64(), int64(2))
+	s.Require().NotEmpty(connectionSpan.Events)
+	s.Require().Equal(graphqlWebsocketOperation, connectionSpan.Events[0].Name)
+
+	operationSpan := findSpanByName(spans, "subscription Greetings")
+	s.Require().NotNil(operationSpan)
+	s.Require().NotEqual(connectionSpan.SpanContext.TraceID(), operationSpan.SpanContext.TraceID())
+	s.Require().Len(operationSpan.Links, 1)
+	s.Require().Equal(connectionSpan.SpanContext.SpanID(), operationSpan.Links[0].SpanContext.SpanID())
+
+	connections := s.findSumByName(graphqlWebsocketConnections)
+	s.Require().NotNil(connections)
+	s.Require().Equal(int64(0), connections.DataPoints[0].Value)
+	messages := s.findHistogramByName(graphqlWebsocketOutgoingMessages)
+	s.Require().NotNil(messages)
+	s.Require().Equal(uint64(1), messages.DataPoints[0].Count)
+}
+
+func (s *TracerSuite) TestWebsocket_InitFailure() {
+	c := s.createTestClientWithWebsocket(New(), transport.Websocket{
+		InitFunc: func(ctx context.Context, payload transport.InitPayload) (context.Context, *transport.InitPayload, error) {
+			return ctx, nil, fmt.Errorf("unauthorized")
+		},
 	})
 
+	sub := c.Websocket("subscription { greetings }")
+	var res struct{ Greetings string }
+	s.Require().Error(sub.Next(&res))
+
+	spans := s.Exporter.GetSpans()
+	connectionSpan := findSpanByName(spans, websocketSpanName)
+	s.Require().NotNil(connectionSpan)
+	s.Require().Equal(codes.Error, connectionSpan.Status.Code)
+	s.Require().Equal("unauthorized", connectionSpan.Status.Description)
+
+	failures := s.findSumByName(graphqlWebsocketInitFailures)
+	s.Require().NotNil(failures)
+	s.Require().Equal(int64(1), failures.DataPoints[0].Value)
+	s.Require().Nil(s.findSumByName(graphqlWebsocketConnections))
+}
+
+func (s *TracerSuite) TestQuery_Transport() {
+	c := s.createTestClient(&Tracer{})
+
 	var res struct{ Greeting string }
 	c.MustPost("query { greeting }", &res)
 
 	spans := s.Exporter.GetSpans()
-	s.Require().Len(spans, 2)
-	span := findSpanByName(spans, "Query.greeting")
-	s.Require().NotNil(span)
-	s.Require().Len(span.Attributes, 5)
+	s.Require().Len(spans, 1)
+	s.Require().Equal("post", findAttributeByName(spans[0].Attributes, graphqlTransport).Value.AsString())
+}
 
-	fieldName := findAttributeByName(span.Attributes, graphqlFieldName)
-	s.Require().NotNil(fieldName)
-	s.Require().Equal(fieldName.Value.AsString(), "greeting")
+func (s *TracerSuite) TestQuery_Transport_GET() {
+	h := s.createTestHandler(&Tracer{})
+
+	rec := httptest.NewRecorder()
+	req := httptest.NewRequest(http.MethodGet, "/?query={greeting}", nil)
+	req.Header.Set("Content-Type", "application/json")
+	h.ServeHTTP(rec, req)
+	s.Require().Equal(http.StatusOK, rec.Code)
+
+	spans := s.Exporter.GetSpans()
+	s.Require().Len(spans, 1)
+	s.Require().Equal("get", findAttributeByName(spans[0].Attributes, graphqlTransport).Value.AsString())
 }
 
-func (s *TracerSuite) TestQuery_WithFieldSpans_Alias() {
+func (s *TracerSuite) TestQuery_Transport_WithoutMethod() {
+	tracer := &Tracer{
+		MeterProvider:  s.MeterProvider,
+		TracerProvider: s.TracerProvider,
+	}
+	h := handler.New(generated.NewExecutableSchema(generated.Config{
+		Resolvers: &testserver.Resolver{},
+	}))
+	h.AddTransport(transport.POST{})
+	h.Use(tracer)
+	c := client.New(h)
+
+	var res struct{ Greeting string }
+	c.MustPost("query { greeting }", &res)
+
+	spans := s.Exporter.GetSpans()
+	s.Require().Len(spans, 1)
+	s.Require().Nil(findAttributeByName(spans[0].Attributes, graphqlTransport))
+}
+
+func (s *TracerSuite) TestQuery_NamedTransport() {
+	tracer := &Tracer{
+		MeterProvider:  s.MeterProvider,
+		TracerProvider: s.TracerProvider,
+	}
+	h := handler.New(generated.NewExecutableSchema(generated.Config{
+		Resolvers: &testserver.Resolver{},
+	}))
+	h.AddTransport(NamedTransport("persisted", transport.POST{}))
+	h.Use(tracer)
+	c := client.New(h)
+
+	var res struct{ Greeting string }
+	c.MustPost("query { greeting }", &res)
+
+	spans := s.Exporter.GetSpans()
+	s.Require().Len(spans, 1)
+	s.Require().Equal("persisted", findAttributeByName(spans[0].Attributes, graphqlTransport).Value.AsString())
+}
+
+func (s *TracerSuite) TestSubscription_Transport() {
+	c := s.createTestClient(&Tracer{})
+
+	sub := c.Websocket("subscription { greetings }")
+	defer sub.Close()
+	var res struct{ Greetings string }
+	s.Require().NoError(sub.Next(&res))
+
+	spans := s.Exporter.GetSpans()
+	s.Require().NotEmpty(spans)
+	s.Require().Equal("websocket", findAttributeByName(spans[0].Attributes, graphqlTransport).Value.AsString())
+}
+
+func (s *TracerSuite) TestQuery_Baggage() {
+	var b baggage.Baggage
 	c := s.createTestClient(&Tracer{
+		ClientBaggage:     true,
 		IncludeFieldSpans: true,
+		OperationBaggage:  true,
+		FieldAttributes: func(ctx context.Context, fc *graphql.FieldContext) []attribute.KeyValue {
+			b = baggage.FromContext(ctx)
+			return nil
+		},
 	})
 
-	var res struct{ MyGreeting string }
-	c.MustPost("query { myGreeting: greeting }", &res)
+	var res struct{ Greeting string }
+	c.MustPost("query GetGreeting { greeting }", &res, client.AddHeader("apollographql-client-name", "web"))
 
-	spans := s.Exporter.GetSpans()
-	s.Require().Len(spans, 2)
-	span := findSpanByName(spans, "Query.greeting")
-	s.Require().NotNil(span)
+	s.Require().Equal("GetGreeting", b.Member("graphql.operation.name").Value())
+	s.Require().Equal("query", b.Member("graphql.operation.type").Value())
+	s.Require().Equal("web", b.Member("graphql.client.name").Value())
+}
 
-	fieldName := findAttributeByName(span.Attributes, graphqlFieldName)
-	s.Require().NotNil(fieldName)
-	s.Require().Equal(fieldName.Value.AsString(), "greeting")
+func (s *TracerSuite) TestQuery_WithoutBaggage() {
+	var b baggage.Baggage
+	c := s.createTestClient(&Tracer{
+		IncludeFieldSpans: true,
+		FieldAttributes: func(ctx context.Context, fc *graphql.FieldContext) []attribute.KeyValue {
+			b = baggage.FromContext(ctx)
+			return nil
+		},
+	})
 
-	fieldAlias := findAttributeByName(span.Attributes, graphqlFieldAlias)
-	s.Require().NotNil(fieldAlias)
-	s.Require().Equal(fieldAlias.Value.AsString(), "myGreeting")
+	var res struct{ Greeting string }
+	c.MustPost("query GetGreeting { greeting }", &res)
+
+	s.Require().Zero(b.Len())
+}
+
+func (s *TracerSuite) TestSpanProcessor() {
+	exporter := tracetest.NewInMemoryExporter()
+	tp := sdktrace.NewTracerProvider(
+		sdktrace.WithSpanProcessor(SpanProcessor{}),
+		sdktrace.WithSyncer(exporter),
+	)
+	h := handler.New(generated.NewExecutableSchema(generated.Config{
+		Resolvers: &testserver.Resolver{},
+	}))
+	h.AddTransport(transport.POST{})
+	h.Use(&Tracer{
+		IncludeFieldSpans: true,
+		MeterProvider:     s.MeterProvider,
+		TracerProvider:    tp,
+	})
+	h.AroundFields(func(ctx context.Context, next graphql.Resolver) (interface{}, error) {
+		_, span := tp.Tracer("database").Start(ctx, "SELECT")
+		span.End()
+		return next(ctx)
+	})
+	c := client.New(h)
+
+	var res struct{ Greeting string }
+	c.MustPost("query GetGreeting { greeting }", &res)
+
+	spans := exporter.GetSpans()
+	s.Require().Len(spans, 3)
+	databaseSpan := findSpanByName(spans, "SELECT")
+	s.Require().NotNil(databaseSpan)
+	s.Require().Equal("GetGreeting", findAttributeByName(databaseSpan.Attributes, semconv.GraphQLOperationNameKey).Value.AsString())
+	s.Require().Equal("query", findAttributeByName(databaseSpan.Attributes, semconv.GraphQLOperationTypeKey).Value.AsString())
+	s.Require().Equal("Query.greeting", findAttributeByName(databaseSpan.Attributes, graphqlSchemaCoordinate).Value.AsString())
+
+	fieldSpan := findSpanByName(spans, "Query.greeting")
+	s.Require().NotNil(fieldSpan)
+	s.Require().Nil(findAttributeByName(fieldSpan.Attributes, graphqlSchemaCoordinate))
 }
 
 func (s *TracerSuite) TestQuery_ParsingError() {
@@ -122,7 +1393,7 @@ func (s *TracerSuite) TestQuery_ParsingError() {
 	s.Require().Len(spans, 1)
 	s.Require().Equal("GraphQL Operation", spans[0].Name)
 	s.Require().Equal(codes.Error, spans[0].Status.Code)
-	s.Require().Len(spans[0].Attributes, 4)
+	s.Require().Len(spans[0].Attributes, 5)
 }
 
 func (s *TracerSuite) TestMutation_SpanCreated() {
@@ -134,7 +1405,7 @@ func (s *TracerSuite) TestMutation_SpanCreated() {
 
 	spans := s.Exporter.GetSpans()
 	s.Require().Len(spans, 1)
-	s.Require().Len(spans[0].Attributes, 6)
+	s.Require().Len(spans[0].Attributes, 15)
 
 	operationName := findAttributeByName(spans[0].Attributes, semconv.GraphQLOperationNameKey)
 	s.Require().NotNil(operationName)
@@ -180,21 +1451,201 @@ func (s *TracerSuite) TestMutation_WithVariables() {
 	s.Require().Equal(nameVariable.Value.AsString(), "gqlgen")
 }
 
+func (s *TracerSuite) TestMutation_WithFieldArguments() {
+	c := s.createTestClient(&Tracer{
+		IncludeFieldArguments: true,
+		IncludeFieldSpans:     true,
+	})
+
+	var res struct{ Greet string }
+	c.MustPost("mutation Greet($name: String!) { greet(name: $name) }", &res, client.Var("name", "gqlgen"))
+
+	spans := s.Exporter.GetSpans()
+	s.Require().Len(spans, 2)
+	span := findSpanByName(spans, "Mutation.greet")
+	s.Require().NotNil(span)
+
+	nameArgument := findAttributeByName(span.Attributes, graphqlFieldArgsPrefix+"name")
+	s.Require().NotNil(nameArgument)
+	s.Require().Equal(nameArgument.Value.AsString(), "gqlgen")
+}
+
+func (s *TracerSuite) TestMutation_WithFieldArguments_Inline() {
+	c := s.createTestClient(&Tracer{
+		IncludeFieldArguments: true,
+		IncludeFieldSpans:     true,
+	})
+
+	var res struct{ Greet string }
+	c.MustPost(`mutation { greet(name: "inline") }`, &res)
+
+	spans := s.Exporter.GetSpans()
+	s.Require().Len(spans, 2)
+	span := findSpanByName(spans, "Mutation.greet")
+	s.Require().NotNil(span)
+
+	nameArgument := findAttributeByName(span.Attributes, graphqlFieldArgsPrefix+"name")
+	s.Require().NotNil(nameArgument)
+	s.Require().Equal(nameArgument.Value.AsString(), "inline")
+}
+
+func (s *TracerSuite) TestQuery_WithFieldArguments_Nullable() {
+	c := s.createTestClient(&Tracer{
+		IncludeFieldArguments: true,
+		IncludeFieldSpans:     true,
+	})
+
+	var res struct{ A, B string }
+	c.MustPost("query { a: localizedGreeting(language: DANISH, formal: true) b: localizedGreeting(language: ENGLISH) }", &res)
+
+	spans := s.Exporter.GetSpans()
+	s.Require().Len(spans, 3)
+	for _, span := range spans {
+		if span.Name != "Query.localizedGreeting" {
+			continue
+		}
+		language := findAttributeByName(span.Attributes, graphqlFieldArgsPrefix+"language")
+		s.Require().NotNil(language)
+		s.Require().Contains([]string{"DANISH", "ENGLISH"}, language.Value.AsString())
+
+		formal := findAttributeByName(span.Attributes, graphqlFieldArgsPrefix+"formal")
+		s.Require().NotNil(formal)
+		if language.Value.AsString() == "DANISH" {
+			s.Require().Equal(attribute.BoolValue(true), formal.Value)
+		} else {
+			s.Require().Equal(attribute.StringValue("null"), formal.Value)
+		}
+	}
+}
+
+func (s *TracerSuite) TestQuery_WithFieldArguments_InputObject() {
+	c := s.createTestClient(&Tracer{
+		IncludeFieldArguments: true,
+		IncludeFieldSpans:     true,
+	})
+
+	var res struct{ CustomGreeting string }
+	c.MustPost(`query { customGreeting(input: {name: "gqlgen", title: "Dr.", language: DANISH}) }`, &res)
+
+	spans := s.Exporter.GetSpans()
+	s.Require().Len(spans, 2)
+	span := findSpanByName(spans, "Query.customGreeting")
+	s.Require().NotNil(span)
+
+	input := findAttributeByName(span.Attributes, graphqlFieldArgsPrefix+"input")
+	s.Require().NotNil(input)
+	s.Require().JSONEq(`{"language": "DANISH", "name": "gqlgen", "title": "Dr."}`, input.Value.AsString())
+}
+
+func (s *TracerSuite) TestMutation_WithoutFieldArguments() {
+	c := s.createTestClient(&Tracer{
+		IncludeFieldSpans: true,
+	})
+
+	var res struct{ Greet string }
+	c.MustPost(`mutation { greet(name: "inline") }`, &res)
+
+	spans := s.Exporter.GetSpans()
+	s.Require().Len(spans, 2)
+	span := findSpanByName(spans, "Mutation.greet")
+	s.Require().NotNil(span)
+
+	nameArgument := findAttributeByName(span.Attributes, graphqlFieldArgsPrefix+"name")
+	s.Require().Nil(nameArgument)
+}
+
 func (s *TracerSuite) createTestClient(tracer *Tracer) *client.Client {
+	return client.New(s.createTestHandler(tracer))
+}
+
+func (s *TracerSuite) createTestClientWithServerSpan(tracer *Tracer) *client.Client {
+	return s.createTestClientWithParentSpan(tracer, s.TracerProvider)
+}
+
+func (s *TracerSuite) createTestClientWithParentSpan(tracer *Tracer, tp trace.TracerProvider) *client.Client {
+	h := s.createTestHandler(tracer)
+	return client.New(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		ctx, span := tp.Tracer("test").Start(r.Context(), "POST", trace.WithSpanKind(trace.SpanKindServer))
+		defer span.End()
+		h.ServeHTTP(w, r.WithContext(ctx))
+	}))
+}
+
+func (s *TracerSuite) createTestClientWithWebsocket(tracer *Tracer, ws transport.Websocket) *client.Client {
+	return client.New(s.createTestHandlerWithWebsocket(tracer, func(t *Tracer) transport.Websocket {
+		return t.Websocket(ws)
+	}))
+}
+
+func (s *TracerSuite) createTestHandler(tracer *Tracer) http.Handler {
+	return s.createTestHandlerWithWebsocket(tracer, func(*Tracer) transport.Websocket {
+		return transport.Websocket{}
+	})
+}
+
+func (s *TracerSuite) createTestHandlerWithWebsocket(tracer *Tracer, websocket func(t *Tracer) transport.Websocket) http.Handler {
+	tracer.MeterProvider = s.MeterProvider
 	tracer.TracerProvider = s.TracerProvider
 	handler := handler.New(generated.NewExecutableSchema(generated.Config{
 		Resolvers: &testserver.Resolver{},
 	}))
-	handler.AddTransport(transport.POST{})
+	handler.AddTransport(websocket(tracer))
+	handler.AddTransport(MethodTransport(transport.GET{}))
+	handler.AddTransport(MethodTransport(transport.POST{}))
 	handler.Use(tracer)
 	handler.Use(extension.FixedComplexityLimit(100))
-	return client.New(handler)
+	return handler
 }
 
 func TestTracerSuite(t *testing.T) {
 	suite.Run(t, new(TracerSuite))
 }
 
+func (s *TracerSuite) findHistogramByName(name string) *metricdata.Histogram[int64] {
+	if m := s.findMetricByName(name); m != nil {
+		if h, ok := m.Data.(metricdata.Histogram[int64]); ok {
+			return &h
+		}
+	}
+	return nil
+}
+
+func (s *TracerSuite) findSumByName(name string) *metricdata.Sum[int64] {
+	if m := s.findMetricByName(name); m != nil {
+		if sum, ok := m.Data.(metricdata.Sum[int64]); ok {
+			return &sum
+		}
+	}
+	return nil
+}
+
+func (s *TracerSuite) findMetricByName(name string) *metricdata.Metrics {
+	var rm metricdata.ResourceMetrics
+	s.Require().NoError(s.MetricReader.Collect(context.Background(), &rm))
+	for _, sm := range rm.ScopeMetrics {
+		for _, m := range sm.Metrics {
+			if m.Name == name {
+				return &m
+			}
+		}
+	}
+	return nil
+}
+
+func TestTracerCache_GetTracer(t *testing.T) {
+	cache := &tracerCache{}
+	tp := sdktrace.NewTracerProvider()
+	assert.Same(t, cache.getTracer(tp), cache.getTracer(tp))
+	assert.NotSame(t, cache.getTracer(tp), cache.getTracer(sdktrace.NewTracerProvider()))
+}
+
+func TestTracerCache_GetInstruments(t *testing.T) {
+	cache := &tracerCache{}
+	mp := sdkmetric.NewMeterProvider()
+	assert.Same(t, cache.getInstruments(mp), cache.getInstruments(mp))
+	assert.NotSame(t, cache.getInstruments(mp), cache.getInstruments(sdkmetric.NewMeterProvider()))
+}
+
 func findAttributeByName(attributes []attribute.KeyValue, name attribute.Key) *attribute.KeyValue {
 	for _, a := range attributes {
 		if a.Key == name {
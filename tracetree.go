@@ -0,0 +1,158 @@
+package gqlgen_opentelemetry
+
+import (
+	"cmp"
+	"context"
+	"slices"
+	"sync"
+	"time"
+
+	"github.com/99designs/gqlgen/graphql"
+	"github.com/vektah/gqlparser/v2/gqlerror"
+	"go.opentelemetry.io/otel/trace"
+)
+
+const traceTreeExtension = "otelTrace"
+
+type traceTreeKey struct{}
+
+type traceTreeNode struct {
+	Name     string           `json:"name"`
+	Path     string           `json:"path,omitempty"`
+	TraceID  string           `json:"traceId,omitempty"`
+	SpanID   string           `json:"spanId,omitempty"`
+	Start    int64            `json:"start"`
+	Duration int64            `json:"duration"`
+	Errors   []string         `json:"errors,omitempty"`
+	Children []*traceTreeNode `json:"children,omitempty"`
+}
+
+type traceTree struct {
+	mu      sync.Mutex
+	start   time.Time
+	root    *traceTreeNode
+	execute *traceTreeNode
+	fields  map[*graphql.FieldContext]*traceTreeNode
+}
+
+func newTraceTree(name string, oc *graphql.OperationContext, sc trace.SpanContext) *traceTree {
+	start := oc.Stats.OperationStart
+	if start.IsZero() {
+		start = time.Now()
+	}
+	tree := &traceTree{
+		start:  start,
+		fields: map[*graphql.FieldContext]*traceTreeNode{},
+		root: &traceTreeNode{
+			Name: name,
+		},
+	}
+	if sc.IsValid() {
+		tree.root.TraceID = sc.TraceID().String()
+		tree.root.SpanID = sc.SpanID().String()
+	}
+	for _, phase := range []struct {
+		name   string
+		timing graphql.TraceTiming
+	}{
+		{"parse", oc.Stats.Parsing},
+		{"validate", oc.Stats.Validation},
+	} {
+		if phase.timing.Start.IsZero() || phase.timing.End.IsZero() {
+			continue
+		}
+		tree.root.Children = append(tree.root.Children, &traceTreeNode{
+			Name:     phase.name,
+			Start:    tree.offset(phase.timing.Start),
+			Duration: int64(phase.timing.End.Sub(phase.timing.Start)),
+		})
+	}
+	return tree
+}
+
+func withTraceTree(ctx context.Context, tree *traceTree) context.Context {
+	return context.WithValue(ctx, traceTreeKey{}, tree)
+}
+
+func getTraceTree(ctx context.Context) *traceTree {
+	tree, _ := ctx.Value(traceTreeKey{}).(*traceTree)
+	return tree
+}
+
+func (t *traceTree) offset(ts time.Time) int64 {
+	return int64(ts.Sub(t.start))
+}
+
+func (t *traceTree) startExecute() {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	t.execute = &traceTreeNode{
+		Name:  "execute",
+		Start: t.offset(time.Now()),
+	}
+	t.root.Children = append(t.root.Children, t.execute)
+}
+
+func (t *traceTree) startField(fc *graphql.FieldContext) *traceTreeNode {
+	node := &traceTreeNode{
+		Name:  fc.Field.ObjectDefinition.Name + "." + fc.Field.Name,
+		Path:  fc.Path().String(),
+		Start: t.offset(time.Now()),
+	}
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	parent := t.execute
+	for p := fc.Parent; p != nil; p = p.Parent {
+		if n, ok := t.fields[p]; ok {
+			parent = n
+			break
+		}
+	}
+	if parent == nil {
+		parent = t.root
+	}
+	parent.Children = append(parent.Children, node)
+	t.fields[fc] = node
+	return node
+}
+
+func (t *traceTree) setSpan(node *traceTreeNode, sc trace.SpanContext) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	node.SpanID = sc.SpanID().String()
+}
+
+func (t *traceTree) endField(node *traceTreeNode, errs gqlerror.List) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	node.Duration = t.offset(time.Now()) - node.Start
+	for _, err := range errs {
+		node.Errors = append(node.Errors, err.Message)
+	}
+}
+
+func (t *traceTree) end(res *graphql.Response) *traceTreeNode {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	now := t.offset(time.Now())
+	if t.execute != nil {
+		t.execute.Duration = now - t.execute.Start
+	}
+	t.root.Duration = now
+	if res != nil {
+		for _, err := range res.Errors {
+			t.root.Errors = append(t.root.Errors, err.Message)
+		}
+	}
+	sortTraceTree(t.root)
+	return t.root
+}
+
+func sortTraceTree(node *traceTreeNode) {
+	slices.SortStableFunc(node.Children, func(a, b *traceTreeNode) int {
+		return cmp.Compare(a.Start, b.Start)
+	})
+	for _, child := range node.Children {
+		sortTraceTree(child)
+	}
+}
@@ -0,0 +1,60 @@
+package gqlgen_opentelemetry
+
+import (
+	"testing"
+	"time"
+
+	"github.com/99designs/gqlgen/graphql"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/vektah/gqlparser/v2/ast"
+	"github.com/vektah/gqlparser/v2/gqlerror"
+	"go.opentelemetry.io/otel/trace"
+)
+
+func TestTraceTree(t *testing.T) {
+	start := time.Now().Add(-time.Second)
+	oc := &graphql.OperationContext{
+		Stats: graphql.Stats{
+			OperationStart: start,
+			Parsing:        graphql.TraceTiming{Start: start, End: start.Add(time.Millisecond)},
+			Validation:     graphql.TraceTiming{Start: start.Add(time.Millisecond), End: start.Add(2 * time.Millisecond)},
+		},
+	}
+	sc := trace.NewSpanContext(trace.SpanContextConfig{
+		TraceID: trace.TraceID{1},
+		SpanID:  trace.SpanID{1},
+	})
+	tree := newTraceTree("query GetUser", oc, sc)
+	tree.startExecute()
+
+	object := &ast.Definition{Name: "Query"}
+	parent := &graphql.FieldContext{Field: graphql.CollectedField{Field: &ast.Field{Name: "user", Alias: "user", ObjectDefinition: object}}}
+	child := &graphql.FieldContext{Parent: parent, Field: graphql.CollectedField{Field: &ast.Field{Name: "name", Alias: "name", ObjectDefinition: &ast.Definition{Name: "User"}}}}
+
+	parentNode := tree.startField(parent)
+	tree.setSpan(parentNode, trace.NewSpanContext(trace.SpanContextConfig{TraceID: trace.TraceID{1}, SpanID: trace.SpanID{2}}))
+	childNode := tree.startField(child)
+	tree.endField(childNode, gqlerror.List{gqlerror.Errorf("no name")})
+	tree.endField(parentNode, nil)
+
+	root := tree.end(&graphql.Response{})
+	assert.Equal(t, "query GetUser", root.Name)
+	assert.Equal(t, sc.TraceID().String(), root.TraceID)
+	require.Len(t, root.Children, 3)
+	assert.Equal(t, "parse", root.Children[0].Name)
+	assert.Equal(t, int64(time.Millisecond), root.Children[0].Duration)
+	assert.Equal(t, "validate", root.Children[1].Name)
+	assert.Equal(t, int64(time.Millisecond), root.Children[1].Start)
+	assert.Equal(t, "execute", root.Children[2].Name)
+
+	require.Len(t, root.Children[2].Children, 1)
+	userNode := root.Children[2].Children[0]
+	assert.Equal(t, "Query.user", userNode.Name)
+	assert.Equal(t, "user", userNode.Path)
+	assert.Equal(t, trace.SpanID{2}.String(), userNode.SpanID)
+	require.Len(t, userNode.Children, 1)
+	assert.Equal(t, "User.name", userNode.Children[0].Name)
+	assert.Equal(t, "user.name", userNode.Children[0].Path)
+	assert.Equal(t, []string{"no name"}, userNode.Children[0].Errors)
+}
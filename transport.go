@@ -0,0 +1,89 @@
+package gqlgen_opentelemetry
+
+import (
+	"context"
+	"mime"
+	"net/http"
+	"strings"
+
+	"github.com/99designs/gqlgen/graphql"
+)
+
+const (
+	transportForm           = "form"
+	transportGET            = "get"
+	transportGraphQL        = "graphql"
+	transportMultipartForm  = "multipart"
+	transportMultipartMixed = "multipart_mixed"
+	transportPOST           = "post"
+	transportSSE            = "sse"
+	transportWebsocket      = "websocket"
+)
+
+type transportKey struct{}
+
+type requestMethodKey struct{}
+
+type namedTransport struct {
+	graphql.Transport
+	name string
+}
+
+type methodTransport struct {
+	graphql.Transport
+}
+
+func NamedTransport(name string, t graphql.Transport) graphql.Transport {
+	return namedTransport{Transport: t, name: name}
+}
+
+func (t namedTransport) Do(w http.ResponseWriter, r *http.Request, exec graphql.GraphExecutor) {
+	t.Transport.Do(w, r.WithContext(context.WithValue(r.Context(), transportKey{}, t.name)), exec)
+}
+
+func MethodTransport(t graphql.Transport) graphql.Transport {
+	return methodTransport{Transport: t}
+}
+
+func (t methodTransport) Do(w http.ResponseWriter, r *http.Request, exec graphql.GraphExecutor) {
+	t.Transport.Do(w, r.WithContext(context.WithValue(r.Context(), requestMethodKey{}, r.Method)), exec)
+}
+
+// getTransport detects the transport from the request headers. The headers of
+// GET and POST requests can look alike, so they are only told apart when the
+// request method was recorded by MethodTransport.
+func getTransport(ctx context.Context, headers http.Header) string {
+	if name, ok := ctx.Value(transportKey{}).(string); ok {
+		return name
+	}
+	if headers == nil {
+		return ""
+	}
+	if headers.Get("Upgrade") != "" {
+		return transportWebsocket
+	}
+	accept := headers.Get("Accept")
+	if strings.Contains(accept, "text/event-stream") {
+		return transportSSE
+	}
+	if strings.Contains(accept, "multipart/mixed") {
+		return transportMultipartMixed
+	}
+	mediaType, _, _ := mime.ParseMediaType(headers.Get("Content-Type"))
+	switch mediaType {
+	case "application/graphql":
+		return transportGraphQL
+	case "application/x-www-form-urlencoded":
+		return transportForm
+	case "multipart/form-data":
+		return transportMultipartForm
+	}
+	switch method, _ := ctx.Value(requestMethodKey{}).(string); method {
+	case http.MethodGet:
+		return transportGET
+	case http.MethodPost:
+		return transportPOST
+	default:
+		return ""
+	}
+}
@@ -0,0 +1,81 @@
+package gqlgen_opentelemetry
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/99designs/gqlgen/graphql"
+	"github.com/99designs/gqlgen/graphql/handler/transport"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestGetTransport(t *testing.T) {
+	values := []struct {
+		headers  http.Header
+		expected string
+	}{
+		{nil, ""},
+		{http.Header{}, ""},
+		{http.Header{"Content-Type": {"application/json"}}, ""},
+		{http.Header{"Content-Type": {"application/json; charset=utf-8"}}, ""},
+		{http.Header{"Content-Type": {"application/graphql"}}, transportGraphQL},
+		{http.Header{"Content-Type": {"application/x-www-form-urlencoded"}}, transportForm},
+		{http.Header{"Content-Type": {"multipart/form-data; boundary=x"}}, transportMultipartForm},
+		{http.Header{"Content-Type": {"text/plain"}}, ""},
+		{http.Header{"Accept": {"multipart/mixed"}, "Content-Type": {"application/json"}}, transportMultipartMixed},
+		{http.Header{"Accept": {"text/event-stream"}, "Content-Type": {"application/json"}}, transportSSE},
+		{http.Header{"Upgrade": {"websocket"}}, transportWebsocket},
+	}
+	for _, v := range values {
+		assert.Equal(t, v.expected, getTransport(context.Background(), v.headers))
+	}
+}
+
+func TestGetTransport_Method(t *testing.T) {
+	values := []struct {
+		method   string
+		headers  http.Header
+		expected string
+	}{
+		{http.MethodGet, http.Header{}, transportGET},
+		{http.MethodGet, http.Header{"Content-Type": {"application/json"}}, transportGET},
+		{http.MethodPost, http.Header{}, transportPOST},
+		{http.MethodPost, http.Header{"Content-Type": {"application/json"}}, transportPOST},
+		{http.MethodPost, http.Header{"Content-Type": {"application/graphql"}}, transportGraphQL},
+		{http.MethodPost, http.Header{"Content-Type": {"multipart/form-data; boundary=x"}}, transportMultipartForm},
+		{http.MethodPost, http.Header{"Accept": {"text/event-stream"}}, transportSSE},
+		{http.MethodPut, http.Header{"Content-Type": {"application/json"}}, ""},
+	}
+	for _, v := range values {
+		ctx := context.WithValue(context.Background(), requestMethodKey{}, v.method)
+		assert.Equal(t, v.expected, getTransport(ctx, v.headers), "%s %v", v.method, v.headers)
+	}
+}
+
+func TestGetTransport_Named(t *testing.T) {
+	ctx := context.WithValue(context.Background(), transportKey{}, "custom")
+	assert.Equal(t, "custom", getTransport(ctx, http.Header{"Content-Type": {"application/json"}}))
+}
+
+func TestMethodTransport(t *testing.T) {
+	var method string
+	tr := MethodTransport(transportFunc(func(w http.ResponseWriter, r *http.Request, exec graphql.GraphExecutor) {
+		method, _ = r.Context().Value(requestMethodKey{}).(string)
+	}))
+	assert.True(t, tr.Supports(httptest.NewRequest(http.MethodGet, "/?query={greeting}", nil)))
+
+	tr.Do(httptest.NewRecorder(), httptest.NewRequest(http.MethodGet, "/", nil), nil)
+	assert.Equal(t, http.MethodGet, method)
+}
+
+type transportFunc func(w http.ResponseWriter, r *http.Request, exec graphql.GraphExecutor)
+
+func (f transportFunc) Supports(r *http.Request) bool {
+	return transport.GET{}.Supports(r)
+}
+
+func (f transportFunc) Do(w http.ResponseWriter, r *http.Request, exec graphql.GraphExecutor) {
+	f(w, r, exec)
+}
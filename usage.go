@@ -0,0 +1,86 @@
+package gqlgen_opentelemetry
+
+import (
+	"encoding/json"
+	"net/http"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/vektah/gqlparser/v2/ast"
+)
+
+type FieldUsage struct {
+	Count    int64     `json:"count"`
+	LastUsed time.Time `json:"lastUsed,omitzero"`
+}
+
+type UsageCollector struct {
+	mu     sync.Mutex
+	fields map[string]FieldUsage
+}
+
+func NewUsageCollector() *UsageCollector {
+	return &UsageCollector{
+		fields: map[string]FieldUsage{},
+	}
+}
+
+func (c *UsageCollector) Snapshot() map[string]FieldUsage {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	snapshot := make(map[string]FieldUsage, len(c.fields))
+	for coordinate, usage := range c.fields {
+		snapshot[coordinate] = usage
+	}
+	return snapshot
+}
+
+func (c *UsageCollector) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet && r.Method != http.MethodHead {
+		w.Header().Set("Allow", "GET, HEAD")
+		http.Error(w, http.StatusText(http.StatusMethodNotAllowed), http.StatusMethodNotAllowed)
+		return
+	}
+	w.Header().Set("Content-Type", "application/json")
+	_ = json.NewEncoder(w).Encode(c.Snapshot())
+}
+
+func (c *UsageCollector) registerSchema(schema *ast.Schema) {
+	if schema == nil {
+		return
+	}
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if c.fields == nil {
+		c.fields = map[string]FieldUsage{}
+	}
+	for _, def := range schema.Types {
+		if def.BuiltIn || strings.HasPrefix(def.Name, "__") || (def.Kind != ast.Object && def.Kind != ast.Interface) {
+			continue
+		}
+		for _, field := range def.Fields {
+			if strings.HasPrefix(field.Name, "__") {
+				continue
+			}
+			coordinate := fieldCoordinate(def.Name, field.Name)
+			if _, ok := c.fields[coordinate]; !ok {
+				c.fields[coordinate] = FieldUsage{}
+			}
+		}
+	}
+}
+
+func (c *UsageCollector) record(coordinates []string, now time.Time) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if c.fields == nil {
+		c.fields = map[string]FieldUsage{}
+	}
+	for _, coordinate := range coordinates {
+		usage := c.fields[coordinate]
+		usage.Count++
+		usage.LastUsed = now
+		c.fields[coordinate] = usage
+	}
+}
@@ -0,0 +1,56 @@
+package gqlgen_opentelemetry
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/zhevron/gqlgen-opentelemetry/v2/testserver"
+	"github.com/zhevron/gqlgen-opentelemetry/v2/testserver/generated"
+)
+
+func TestUsageCollector_Snapshot(t *testing.T) {
+	schema := generated.NewExecutableSchema(generated.Config{
+		Resolvers: &testserver.Resolver{},
+	}).Schema()
+	now := time.Now()
+
+	c := NewUsageCollector()
+	c.registerSchema(schema)
+	c.record([]string{"Query.greeting", "Query.node"}, now)
+	c.record([]string{"Query.greeting"}, now)
+
+	snapshot := c.Snapshot()
+	assert.Equal(t, FieldUsage{Count: 2, LastUsed: now}, snapshot["Query.greeting"])
+	assert.Equal(t, FieldUsage{Count: 1, LastUsed: now}, snapshot["Query.node"])
+	assert.Equal(t, FieldUsage{}, snapshot["Mutation.greet"])
+	assert.Equal(t, FieldUsage{}, snapshot["Node.id"])
+	assert.Equal(t, FieldUsage{}, snapshot["Person.name"])
+	assert.NotContains(t, snapshot, "__Type.name")
+}
+
+func TestUsageCollector_ServeHTTP(t *testing.T) {
+	c := NewUsageCollector()
+	c.record([]string{"Query.greeting"}, time.Now())
+
+	rec := httptest.NewRecorder()
+	c.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/", nil))
+	assert.Equal(t, http.StatusOK, rec.Code)
+	assert.Equal(t, "application/json", rec.Header().Get("Content-Type"))
+
+	var report map[string]FieldUsage
+	require.NoError(t, json.NewDecoder(rec.Body).Decode(&report))
+	assert.Equal(t, int64(1), report["Query.greeting"].Count)
+}
+
+func TestUsageCollector_ServeHTTP_MethodNotAllowed(t *testing.T) {
+	c := NewUsageCollector()
+
+	rec := httptest.NewRecorder()
+	c.ServeHTTP(rec, httptest.NewRequest(http.MethodPost, "/", nil))
+	assert.Equal(t, http.StatusMethodNotAllowed, rec.Code)
+}
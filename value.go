@@ -1,13 +1,19 @@
 package gqlgen_opentelemetry
 
 import (
+	"encoding/json"
 	"fmt"
+	"reflect"
 
 	"go.opentelemetry.io/otel/attribute"
 )
 
+const nullAttributeValue = "null"
+
 func makeAttributeValue(value interface{}) attribute.Value {
 	switch v := value.(type) {
+	case nil:
+		return attribute.StringValue(nullAttributeValue)
 	case bool:
 		return attribute.BoolValue(v)
 	case float32:
@@ -24,63 +30,108 @@ func makeAttributeValue(value interface{}) attribute.Value {
 		return attribute.StringValue(v)
 	case []interface{}:
 		return makeAttributeSliceValue(v)
+	case []bool:
+		return attribute.BoolSliceValue(v)
+	case []float64:
+		return attribute.Float64SliceValue(v)
+	case []int:
+		return attribute.IntSliceValue(v)
+	case []int64:
+		return attribute.Int64SliceValue(v)
+	case []string:
+		return attribute.StringSliceValue(v)
+	default:
+		return makeReflectAttributeValue(reflect.ValueOf(v))
+	}
+}
+
+// makeReflectAttributeValue converts the coerced Go values of arguments, such as
+// pointers for nullable arguments, enums and input objects.
+func makeReflectAttributeValue(v reflect.Value) attribute.Value {
+	switch v.Kind() {
+	case reflect.Pointer, reflect.Interface:
+		if v.IsNil() {
+			return attribute.StringValue(nullAttributeValue)
+		}
+		return makeAttributeValue(v.Elem().Interface())
+	case reflect.Bool:
+		return attribute.BoolValue(v.Bool())
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return attribute.Int64Value(v.Int())
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32:
+		return attribute.Int64Value(int64(v.Uint()))
+	case reflect.Float32, reflect.Float64:
+		return attribute.Float64Value(v.Float())
+	case reflect.String:
+		return attribute.StringValue(v.String())
+	case reflect.Slice, reflect.Array:
+		if v.Kind() == reflect.Slice && v.IsNil() {
+			return attribute.StringValue(nullAttributeValue)
+		}
+		values := make([]interface{}, v.Len())
+		for i := range values {
+			values[i] = v.Index(i).Interface()
+		}
+		return makeAttributeSliceValue(values)
 	default:
-		return attribute.StringValue(fmt.Sprintf("%+v", v))
+		return attribute.StringValue(stringifyValue(v.Interface()))
 	}
 }
 
+func stringifyValue(value interface{}) string {
+	if s, ok := value.(fmt.Stringer); ok {
+		return s.String()
+	}
+	if b, err := json.Marshal(value); err == nil {
+		return string(b)
+	}
+	return fmt.Sprintf("%+v", value)
+}
+
 func makeAttributeSliceValue(value []interface{}) attribute.Value {
-	if len(value) == 0 {
-		return attribute.StringSliceValue([]string{})
+	values := make([]attribute.Value, len(value))
+	for i, v := range value {
+		values[i] = makeAttributeValue(v)
 	}
-	switch value[0].(type) {
-	case bool:
-		arr := make([]bool, len(value))
-		for i, v := range value {
-			arr[i] = v.(bool)
+	switch getSliceElementType(values) {
+	case attribute.BOOL:
+		arr := make([]bool, len(values))
+		for i, v := range values {
+			arr[i] = v.AsBool()
 		}
 		return attribute.BoolSliceValue(arr)
-	case float32:
-		arr := make([]float64, len(value))
-		for i, v := range value {
-			arr[i] = float64(v.(float32))
-		}
-		return attribute.Float64SliceValue(arr)
-	case float64:
-		arr := make([]float64, len(value))
-		for i, v := range value {
-			arr[i] = v.(float64)
+	case attribute.FLOAT64:
+		arr := make([]float64, len(values))
+		for i, v := range values {
+			arr[i] = v.AsFloat64()
 		}
 		return attribute.Float64SliceValue(arr)
-	case int:
-		arr := make([]int64, len(value))
-		for i, v := range value {
-			arr[i] = int64(v.(int))
-		}
-		return attribute.Int64SliceValue(arr)
-	case int32:
-		arr := make([]int64, len(value))
-		for i, v := range value {
-			arr[i] = int64(v.(int32))
-		}
-		return attribute.Int64SliceValue(arr)
-	case int64:
-		arr := make([]int64, len(value))
-		for i, v := range value {
-			arr[i] = v.(int64)
+	case attribute.INT64:
+		arr := make([]int64, len(values))
+		for i, v := range values {
+			arr[i] = v.AsInt64()
 		}
 		return attribute.Int64SliceValue(arr)
-	case string:
-		arr := make([]string, len(value))
-		for i, v := range value {
-			arr[i] = v.(string)
-		}
-		return attribute.StringSliceValue(arr)
 	default:
-		arr := make([]string, len(value))
-		for i, v := range value {
-			arr[i] = fmt.Sprintf("%+v", v)
+		arr := make([]string, len(values))
+		for i, v := range values {
+			arr[i] = v.Emit()
 		}
 		return attribute.StringSliceValue(arr)
 	}
 }
+
+// getSliceElementType returns the type shared by all values, or STRING when
+// they differ, so mixed lists are recorded as strings.
+func getSliceElementType(values []attribute.Value) attribute.Type {
+	if len(values) == 0 {
+		return attribute.STRING
+	}
+	t := values[0].Type()
+	for _, v := range values[1:] {
+		if v.Type() != t {
+			return attribute.STRING
+		}
+	}
+	return t
+}
@@ -11,6 +11,13 @@ type valueTestStruct struct {
 	Value string
 }
 
+type valueTestInput struct {
+	Name  string  `json:"name"`
+	Title *string `json:"title,omitempty"`
+}
+
+type valueTestEnum string
+
 type valueTestTable []struct {
 	input        interface{}
 	expectedType attribute.Type
@@ -26,6 +33,11 @@ func TestMakeAttributeValue(t *testing.T) {
 		{int64(1), attribute.INT64},
 		{"test", attribute.STRING},
 		{valueTestStruct{Value: "test"}, attribute.STRING},
+		{[]bool{true}, attribute.BOOLSLICE},
+		{[]float64{1.5}, attribute.FLOAT64SLICE},
+		{[]int{1}, attribute.INT64SLICE},
+		{[]int64{1}, attribute.INT64SLICE},
+		{[]string{"test"}, attribute.STRINGSLICE},
 	}
 	for _, v := range values {
 		value := makeAttributeValue(v.input)
@@ -52,3 +64,54 @@ func TestMakeAttributeSliceValue(t *testing.T) {
 		assert.Len(t, value.AsInterface(), len(slice))
 	}
 }
+
+func TestMakeAttributeValue_Pointers(t *testing.T) {
+	formal := true
+	title := "Dr."
+	var nilInput *valueTestInput
+
+	assert.Equal(t, attribute.BoolValue(true), makeAttributeValue(&formal))
+	assert.Equal(t, attribute.StringValue("null"), makeAttributeValue((*bool)(nil)))
+	assert.Equal(t, attribute.StringValue("null"), makeAttributeValue(nil))
+	assert.Equal(t, attribute.StringValue("null"), makeAttributeValue(nilInput))
+	assert.Equal(t, attribute.StringValue("DANISH"), makeAttributeValue(valueTestEnum("DANISH")))
+	assert.Equal(t,
+		attribute.StringValue(`{"name":"gqlgen","title":"Dr."}`),
+		makeAttributeValue(&valueTestInput{Name: "gqlgen", Title: &title}),
+	)
+	assert.Equal(t,
+		attribute.StringValue(`{"a":1}`),
+		makeAttributeValue(map[string]interface{}{"a": 1}),
+	)
+}
+
+func TestMakeAttributeValue_TypedSlices(t *testing.T) {
+	title := "Dr."
+
+	assert.Equal(t,
+		attribute.StringSliceValue([]string{"DANISH", "ENGLISH"}),
+		makeAttributeValue([]valueTestEnum{"DANISH", "ENGLISH"}),
+	)
+	assert.Equal(t,
+		attribute.StringSliceValue([]string{"Dr.", "null"}),
+		makeAttributeValue([]*string{&title, nil}),
+	)
+}
+
+func TestMakeAttributeSliceValue_Mixed(t *testing.T) {
+	assert.NotPanics(t, func() {
+		assert.Equal(t,
+			attribute.StringSliceValue([]string{"1", "test", "true"}),
+			makeAttributeSliceValue([]interface{}{1, "test", true}),
+		)
+	})
+	assert.Equal(t,
+		attribute.StringSliceValue([]string{"1", "null"}),
+		makeAttributeSliceValue([]interface{}{1, nil}),
+	)
+	assert.Equal(t,
+		attribute.Int64SliceValue([]int64{1, 2}),
+		makeAttributeSliceValue([]interface{}{1, int64(2)}),
+	)
+	assert.Equal(t, attribute.STRINGSLICE, makeAttributeSliceValue([]interface{}{}).Type())
+}
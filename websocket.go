@@ -0,0 +1,173 @@
+package gqlgen_opentelemetry
+
+import (
+	"context"
+	"strconv"
+	"sync"
+
+	"github.com/99designs/gqlgen/graphql/handler/transport"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/baggage"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/metric"
+	"go.opentelemetry.io/otel/trace"
+)
+
+const websocketSpanName = "websocket"
+
+var websocketCloseReasons = map[int]string{
+	1000: "normal closure",
+	1001: "going away",
+	1002: "protocol error",
+	1003: "unsupported data",
+	1005: "no status received",
+	1006: "abnormal closure",
+	1007: "invalid payload data",
+	1008: "policy violation",
+	1009: "message too big",
+	1010: "mandatory extension",
+	1011: "internal server error",
+	1012: "service restart",
+	1013: "try again later",
+	1015: "TLS handshake",
+	4400: "bad request",
+	4401: "unauthorized",
+	4403: "forbidden",
+	4408: "connection initialisation timeout",
+	4409: "subscriber already exists",
+	4429: "too many initialisation requests",
+	4500: "internal server error",
+}
+
+type websocketConnectionKey struct{}
+
+type websocketConnection struct {
+	instruments *instruments
+	span        trace.Span
+
+	mu               sync.Mutex
+	closed           bool
+	outgoingMessages int
+	operations       int
+}
+
+func (t Tracer) Websocket(ws transport.Websocket) transport.Websocket {
+	initFunc, errorFunc, closeFunc := ws.InitFunc, ws.ErrorFunc, ws.CloseFunc
+	ws.InitFunc = func(ctx context.Context, payload transport.InitPayload) (context.Context, *transport.InitPayload, error) {
+		spanCtx := ctx
+		var links []trace.Link
+		if t.ExtensionPropagation {
+			spanCtx, links = t.extractExtensions(ctx, payload)
+		}
+		_, span := t.getTracer(spanCtx).Start(spanCtx, websocketSpanName, trace.WithSpanKind(trace.SpanKindServer), trace.WithLinks(links...), trace.WithAttributes(baseAttributes...))
+		i := t.getInstruments()
+		var ack *transport.InitPayload
+		if initFunc != nil {
+			var err error
+			ctx, ack, err = initFunc(ctx, payload)
+			if err != nil {
+				span.SetStatus(codes.Error, err.Error())
+				span.RecordError(err)
+				span.End()
+				i.websocketInitFailures.Add(ctx, 1)
+				return ctx, ack, err
+			}
+		}
+		if b := baggage.FromContext(spanCtx); b.Len() > 0 && baggage.FromContext(ctx).Len() == 0 {
+			ctx = baggage.ContextWithBaggage(ctx, b)
+		}
+		i.websocketConnections.Add(ctx, 1)
+		return withWebsocketConnection(ctx, &websocketConnection{
+			instruments: i,
+			span:        span,
+		}), ack, nil
+	}
+	ws.ErrorFunc = func(ctx context.Context, err error) {
+		if c := getWebsocketConnection(ctx); c != nil {
+			c.span.RecordError(err)
+		}
+		if errorFunc != nil {
+			errorFunc(ctx, err)
+		}
+	}
+	ws.CloseFunc = func(ctx context.Context, closeCode int) {
+		if c := getWebsocketConnection(ctx); c != nil {
+			c.close(ctx, closeCode)
+		}
+		if closeFunc != nil {
+			closeFunc(ctx, closeCode)
+		}
+	}
+	return ws
+}
+
+func withWebsocketConnection(ctx context.Context, c *websocketConnection) context.Context {
+	return context.WithValue(ctx, websocketConnectionKey{}, c)
+}
+
+func getWebsocketConnection(ctx context.Context) *websocketConnection {
+	c, _ := ctx.Value(websocketConnectionKey{}).(*websocketConnection)
+	return c
+}
+
+func (c *websocketConnection) recordOperation(attrs ...attribute.KeyValue) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.operations++
+	c.span.AddEvent(graphqlWebsocketOperation, trace.WithAttributes(attrs...))
+}
+
+func (c *websocketConnection) recordOutgoingMessage() trace.Link {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.outgoingMessages++
+	return trace.Link{SpanContext: c.span.SpanContext()}
+}
+
+func (c *websocketConnection) close(ctx context.Context, closeCode int) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if c.closed {
+		return
+	}
+	c.closed = true
+	reason, ok := websocketCloseReasons[closeCode]
+	if !ok {
+		reason = strconv.Itoa(closeCode)
+	}
+	c.span.SetAttributes(
+		graphqlWebsocketCloseCode.Int(closeCode),
+		graphqlWebsocketCloseReason.String(reason),
+		graphqlWebsocketOutgoingMessageCount.Int(c.outgoingMessages),
+		graphqlWebsocketOperationCount.Int(c.operations),
+	)
+	if closeCode != 1000 && closeCode != 1001 {
+		c.span.SetStatus(codes.Error, reason)
+	}
+	c.span.End()
+	c.instruments.websocketConnections.Add(ctx, -1)
+	c.instruments.websocketOutgoingMessages.Record(ctx, int64(c.outgoingMessages), metric.WithAttributes(graphqlWebsocketCloseCode.Int(closeCode)))
+}
+
+func (t Tracer) extractInitPayload(ctx context.Context, links []trace.Link) (context.Context, []trace.Link) {
+	if getWebsocketConnection(ctx) != nil {
+		return ctx, links
+	}
+	payload := transport.GetInitPayload(ctx)
+	if len(payload) == 0 {
+		return ctx, links
+	}
+	propagator := t.getPropagator()
+	carrier := makeExtensionsCarrier(payload, propagator.Fields())
+	if len(carrier) == 0 {
+		return ctx, links
+	}
+	connectionCtx := propagator.Extract(context.Background(), carrier)
+	if b := baggage.FromContext(connectionCtx); b.Len() > 0 && baggage.FromContext(ctx).Len() == 0 {
+		ctx = baggage.ContextWithBaggage(ctx, b)
+	}
+	if sc := trace.SpanContextFromContext(connectionCtx); sc.IsValid() && !sc.Equal(trace.SpanContextFromContext(ctx)) {
+		links = append(links, trace.Link{SpanContext: sc})
+	}
+	return ctx, links
+}